@@ -30,13 +30,19 @@ type User struct {
 	Username  string         `validate:"required,min=3,max=20,username_format"`
 	Email     string         `validate:"required,email"`
 	Age       int            `validate:"required,gte=18,lte=100"`
-	Status    UserStatus     `validate:"required"`
+	Status    UserStatus     `validate:"enum_range=0 2"`
 	Phone     string         `validate:"required,phone_format"`
 	NickName  sql.NullString `validate:"omitempty"`
 	FirstName string         `json:"first_name"`
 	LastName  string         `json:"last_name"`
 }
 
+// Contact struct demonstrates the baked-in phone_canonical validation, which goes
+// beyond phone_format by rewriting a valid number to its E.164 form in place
+type Contact struct {
+	Phone string `validate:"required,phone_canonical=CN"`
+}
+
 var validate *validator.Validate
 
 func main() {
@@ -143,6 +149,16 @@ func main() {
 	} else {
 		fmt.Println("✓ Validation passed")
 	}
+
+	// Test case 6: Canonical phone number, a pointer is required so the field can be rewritten
+	contact := Contact{Phone: "138 0013 8000"}
+
+	fmt.Println("\n=== Test 6: Canonical Phone Number ===")
+	if err := validate.Struct(&contact); err != nil {
+		fmt.Printf("Validation failed: %v\n", err)
+	} else {
+		fmt.Printf("✓ Validation passed, phone rewritten to %s\n", contact.Phone)
+	}
 }
 
 // validateUsernameFormat is a field-level custom validation for username format
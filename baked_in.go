@@ -1,25 +1,36 @@
 package validator
 
 import (
+	"archive/zip"
 	"bytes"
 	"cmp"
 	"context"
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"io"
 	"io/fs"
+	"math"
+	"mime/multipart"
 	"net"
 	"net/mail"
 	"net/url"
 	"os"
+	"path"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+	"unicode"
+	"unicode/utf16"
 	"unicode/utf8"
 
 	"golang.org/x/crypto/sha3"
@@ -251,6 +262,87 @@ var (
 		"spicedb":                       isSpiceDB,
 		"ein":                           isEIN,
 		"validateFn":                    isValidateFn,
+		"char_classes":                  hasCharClasses,
+		"sql_identifier":                isSQLIdentifier,
+		"business_hours":                isWithinBusinessHours,
+		"weekday":                       isWeekday,
+		"json_stable":                   isJSONStable,
+		"json_max_depth":                hasJSONMaxDepth,
+		"sms_segments":                  hasMaxSMSSegments,
+		"ip_or_cidr":                    isIPOrCIDR,
+		"pin_strength":                  isStrongPIN,
+		"power_of_two":                  isPowerOfTwo,
+		"power_of":                      isPowerOf,
+		"required_slice_if":             requiredSliceIf,
+		"luhn":                          hasLuhn,
+		"imei":                          isIMEI,
+		"imeisv":                        isIMEISV,
+		"geo_precision":                 hasMaxGeoPrecision,
+		"slice_bytes":                   hasMaxSliceBytes,
+		"host_port":                     isHostPort,
+		"json_pointer":                  isJSONPointer,
+		"max_unique":                    hasMaxUnique,
+		"money_nonneg":                  isMoneyNonNeg,
+		"alphanum_plus":                 isAlphanumPlus,
+		"spdx_license":                  isSPDXLicense,
+		"enum_range":                    isEnumRange,
+		"phone_canonical":               isPhoneCanonical,
+		"iban":                          isIBAN,
+		"vat":                           isVAT,
+		"no_bidi_control":               hasNoBidiControl,
+		"aligned_to":                    isAlignedTo,
+		"dsn":                           isDSN,
+		"tuple":                         isTuple,
+		"datetime_any":                  isDatetimeAny,
+		"min_increment":                 hasMinIncrement,
+		"dns_name_strict":               isDNSNameStrict,
+		"percent_split":                 isPercentSplit,
+		"aspect_ratio":                  hasAspectRatio,
+		"balanced_brackets":             hasBalancedBrackets,
+		"theme_json":                    isThemeJSON,
+		"quantity":                      isQuantity,
+		"template_name":                 isTemplateName,
+		"max_utf16_units":               hasMaxUTF16Units,
+		"dns_label":                     isDNSLabel,
+		"locales_cover":                 hasLocalesCover,
+		"float32_safe":                  isFloat32Safe,
+		"no_mixed_scripts":              hasNoMixedScripts,
+		"http_status":                   isHTTPStatus,
+		"proto_enum":                    isProtoEnum,
+		"sum_field_max":                 hasSumFieldMax,
+		"language_simple":               isLanguageSimple,
+		"between_fields":                isBetweenFields,
+		"percent_encoded":               isPercentEncoded,
+		"normalized_tag":                isNormalizedTag,
+		"port_range":                    isPortRange,
+		"shell_safe":                    isShellSafe,
+		"js_safe_ints":                  hasJSSafeInts,
+		"max_repeat":                    hasMaxRepeat,
+		"phone_ext":                     isPhoneExt,
+		"email_rfc_limits":              isEmailRFCLimits,
+		"crockford32":                   isCrockford32,
+		"mod11":                         hasMod11,
+		"coupon_code":                   isCouponCode,
+		"feature_flag":                  isFeatureFlag,
+		"iso8601_duration":              isISO8601Duration,
+		"avatar_url":                    isAvatarURL,
+		"jsonschema":                    isJSONSchema,
+	}
+
+	// bakedInValidatorsCtx is the default map of ValidationFunc which
+	// require the context.Context passed to validation.
+	bakedInValidatorsCtx = map[string]FuncCtx{
+		nullableTag:         isNullable,
+		"fk_exists":         isFKExisting,
+		"eq_current_user":   isEqCurrentUser,
+		"rate_limit":        isWithinRateLimit,
+		"sequence_gt_key":   isSequenceGtKey,
+		"version_gt_stored": isVersionGtStored,
+		"hmac_sig":          isHMACSig,
+		"unique_nonce":      isUniqueNonce,
+		"safe_archive":      isSafeArchive,
+		"named_format":      isNamedFormat,
+		"content_length_eq": isContentLengthEq,
 	}
 )
 
@@ -2730,18 +2822,19 @@ func isDirPath(fl FieldLevel) bool {
 
 // isJSON is the validation function for validating if the current field's value is a valid json string.
 func isJSON(fl FieldLevel) bool {
-	field := fl.Field()
+	return json.Valid(jsonFieldBytes(fl.Field()))
+}
 
+// jsonFieldBytes returns the raw JSON held by a string or []byte field or panics for any other type.
+func jsonFieldBytes(field reflect.Value) []byte {
 	switch field.Kind() {
 	case reflect.String:
-		val := field.String()
-		return json.Valid([]byte(val))
+		return []byte(field.String())
 	case reflect.Slice:
 		fieldType := field.Type()
 
 		if fieldType.ConvertibleTo(byteSliceType) {
-			b := getValue(field.Convert(byteSliceType)).([]byte)
-			return json.Valid(b)
+			return getValue(field.Convert(byteSliceType)).([]byte)
 		}
 	}
 
@@ -3169,3 +3262,2604 @@ func tryCallValidateFn(field reflect.Value, validateFn string) (bool, error) {
 			validateFn, field.Type().String(), errMethodReturnInvalidType, firstReturnValue.Type().String())
 	}
 }
+
+// hasCharClasses is the validation function for validating if the current field's value contains at least the
+// minimum number of distinct character classes out of lowercase, uppercase, digit, symbol and unicode letter.
+func hasCharClasses(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	minClasses := asUint(strings.TrimPrefix(fl.Param(), "min="))
+
+	var lower, upper, digit, symbol, letter bool
+	for _, r := range field.String() {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case unicode.IsLetter(r):
+			letter = true
+		case !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	var count uint64
+	for _, present := range []bool{lower, upper, digit, symbol, letter} {
+		if present {
+			count++
+		}
+	}
+	return count >= minClasses
+}
+
+// isSQLIdentifier is the validation function for validating if the current field's value is a plain SQL identifier,
+// optionally qualified by a single dot eg. "table.column", which is safe to interpolate into a query as an identifier.
+func isSQLIdentifier(fl FieldLevel) bool {
+	return sqlIdentifierRegex().MatchString(fl.Field().String())
+}
+
+var (
+	timeLocationCache       = map[string]*time.Location{}
+	timeLocationCacheRWLock = sync.RWMutex{}
+)
+
+// loadTimeLocation returns the named time.Location, caching the result as loading
+// the time zone database is expensive, and panics if the location is unknown.
+func loadTimeLocation(name string) *time.Location {
+	timeLocationCacheRWLock.RLock()
+	loc, ok := timeLocationCache[name]
+	timeLocationCacheRWLock.RUnlock()
+	if !ok {
+		var err error
+		loc, err = time.LoadLocation(name)
+		panicIf(err)
+
+		timeLocationCacheRWLock.Lock()
+		timeLocationCache[name] = loc
+		timeLocationCacheRWLock.Unlock()
+	}
+	return loc
+}
+
+// asTime returns the field as a time.Time or panics if it isn't convertible to one.
+func asTime(field reflect.Value) time.Time {
+	if !field.Type().ConvertibleTo(timeType) {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+	return getValue(field.Convert(timeType)).(time.Time)
+}
+
+// parseClock parses a "15:04" time of day into the number of seconds since midnight or panics if it can't.
+func parseClock(s string) int {
+	t, err := time.Parse("15:04", s)
+	panicIf(err)
+	return t.Hour()*3600 + t.Minute()*60
+}
+
+// isWithinBusinessHours is the validation function for validating if the current field's time of day,
+// in the optionally provided time zone, falls within the window [start, end). Windows crossing midnight are supported.
+func isWithinBusinessHours(fl FieldLevel) bool {
+	t := asTime(fl.Field())
+
+	params := strings.Fields(fl.Param())
+	if len(params) == 0 || len(params) > 2 {
+		panic(fmt.Sprintf("Bad param for business_hours %s", fl.Param()))
+	}
+
+	bounds := strings.SplitN(params[0], "-", 2)
+	if len(bounds) != 2 {
+		panic(fmt.Sprintf("Bad param for business_hours %s", fl.Param()))
+	}
+
+	start, end := parseClock(bounds[0]), parseClock(bounds[1])
+	if start == end {
+		panic(fmt.Sprintf("Bad param for business_hours %s", fl.Param()))
+	}
+
+	if len(params) == 2 {
+		t = t.In(loadTimeLocation(strings.TrimPrefix(params[1], "tz=")))
+	}
+
+	secs := t.Hour()*3600 + t.Minute()*60 + t.Second()
+
+	if start < end {
+		return secs >= start && secs < end
+	}
+	return secs >= start || secs < end
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// isWeekday is the validation function for validating if the current field's date, in the optionally
+// provided time zone, falls on one of the provided weekdays.
+func isWeekday(fl FieldLevel) bool {
+	t := asTime(fl.Field())
+
+	var allowed [7]bool
+	for _, p := range strings.Fields(fl.Param()) {
+		if strings.HasPrefix(p, "tz=") {
+			t = t.In(loadTimeLocation(p[3:]))
+			continue
+		}
+
+		day, ok := weekdayNames[strings.ToLower(p)]
+		if !ok {
+			panic(fmt.Sprintf("Bad param for weekday %s", p))
+		}
+		allowed[day] = true
+	}
+
+	return allowed[t.Weekday()]
+}
+
+// isJSONStable is the validation function for validating if the current field's value is JSON which survives
+// a decode and re-encode unchanged, ignoring insignificant whitespace. Object keys keep their order, duplicate
+// keys are rejected and strings and numbers must already be in their canonical encoding.
+func isJSONStable(fl FieldLevel) bool {
+	b := jsonFieldBytes(fl.Field())
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, b); err != nil {
+		return false
+	}
+
+	type frame struct {
+		object bool
+		count  int
+		keys   map[string]struct{}
+	}
+
+	var (
+		stack []*frame
+		out   bytes.Buffer
+	)
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteByte(byte(d))
+			continue
+		}
+
+		if len(stack) > 0 {
+			f := stack[len(stack)-1]
+
+			if f.object && f.count%2 == 0 {
+				// object key
+				key := tok.(string)
+				if _, dup := f.keys[key]; dup {
+					return false
+				}
+				f.keys[key] = struct{}{}
+			}
+
+			if f.count > 0 {
+				if f.object && f.count%2 == 1 {
+					out.WriteByte(':')
+				} else {
+					out.WriteByte(',')
+				}
+			}
+			f.count++
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			out.WriteByte(byte(t))
+			stack = append(stack, &frame{object: t == '{', keys: make(map[string]struct{})})
+		case json.Number:
+			if i, err := strconv.ParseInt(string(t), 10, 64); err == nil {
+				out.WriteString(strconv.FormatInt(i, 10))
+				continue
+			}
+
+			f, err := t.Float64()
+			if err != nil {
+				return false
+			}
+
+			enc, err := json.Marshal(f)
+			if err != nil {
+				return false
+			}
+			out.Write(enc)
+		case string:
+			enc := json.NewEncoder(&out)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(t); err != nil {
+				return false
+			}
+			out.Truncate(out.Len() - 1) // Encode terminates each value with a newline
+		default:
+			enc, _ := json.Marshal(t)
+			out.Write(enc)
+		}
+	}
+
+	return bytes.Equal(out.Bytes(), compact.Bytes())
+}
+
+// hasJSONMaxDepth is the validation function for validating if the current field's value is JSON whose
+// object and array nesting does not exceed the provided depth. Tokens are streamed so that deeply
+// nested input is rejected without first being decoded in full.
+func hasJSONMaxDepth(fl FieldLevel) bool {
+	b := jsonFieldBytes(fl.Field())
+	maxDepth := asInt(fl.Param())
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+
+	var depth int64
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			if depth > maxDepth {
+				return false
+			}
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			// the top level value is complete and must be the only one
+			_, err = dec.Token()
+			return err == io.EOF
+		}
+	}
+}
+
+const (
+	gsm7BasicChars     = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7ExtensionChars = "\f^{}\\[~]|€"
+)
+
+// smsSegments returns the number of SMS segments required to send the message. GSM-7 encoding is
+// used when every character is in the GSM 03.38 alphabet, where extension characters cost two septets,
+// otherwise UCS-2 is used where characters outside the BMP cost two code units. Characters which
+// take two units are never split across segments.
+func smsSegments(msg string) int {
+	gsm := true
+	for _, r := range msg {
+		if !strings.ContainsRune(gsm7BasicChars, r) && !strings.ContainsRune(gsm7ExtensionChars, r) {
+			gsm = false
+			break
+		}
+	}
+
+	single, multi := 160, 153
+	if !gsm {
+		single, multi = 70, 67
+	}
+
+	costs := make([]int, 0, len(msg))
+	for _, r := range msg {
+		switch {
+		case !gsm:
+			costs = append(costs, utf16.RuneLen(r))
+		case strings.ContainsRune(gsm7ExtensionChars, r):
+			costs = append(costs, 2)
+		default:
+			costs = append(costs, 1)
+		}
+	}
+
+	var total int
+	for _, c := range costs {
+		total += c
+	}
+
+	if total == 0 {
+		return 0
+	}
+	if total <= single {
+		return 1
+	}
+
+	segments, used := 1, 0
+	for _, c := range costs {
+		if used+c > multi {
+			segments++
+			used = 0
+		}
+		used += c
+	}
+	return segments
+}
+
+// hasMaxSMSSegments is the validation function for validating if the current field's value can be sent in no
+// more than the provided number of SMS segments.
+func hasMaxSMSSegments(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	return uint64(smsSegments(field.String())) <= asUint(strings.TrimPrefix(fl.Param(), "max="))
+}
+
+// isIPOrCIDR is the validation function for validating if the field's value is either a valid IP address or a valid CIDR.
+func isIPOrCIDR(fl FieldLevel) bool {
+	val := fl.Field().String()
+
+	if strings.Contains(val, "/") {
+		_, _, err := net.ParseCIDR(val)
+		return err == nil
+	}
+
+	return net.ParseIP(val) != nil
+}
+
+// commonPINs are frequently chosen PINs which are neither repeated nor sequential digits.
+var commonPINs = map[string]struct{}{
+	"1212": {}, "1004": {}, "2000": {}, "6969": {}, "1122": {},
+	"1313": {}, "2580": {}, "0852": {}, "1010": {}, "2001": {},
+	"1230": {}, "0007": {}, "1969": {}, "2468": {}, "1357": {},
+	"112233": {}, "121212": {}, "123123": {}, "696969": {}, "520520": {},
+}
+
+// isStrongPIN is the validation function for validating if the current field's value is a numeric PIN which is not
+// made of a single repeated digit, not an ascending or descending sequence and not a common PIN. Additional common
+// PINs may be provided as a space separated param.
+func isStrongPIN(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	pin := field.String()
+	if len(pin) == 0 || !numberRegex().MatchString(pin) {
+		return false
+	}
+
+	if _, ok := commonPINs[pin]; ok {
+		return false
+	}
+
+	for _, p := range strings.Fields(fl.Param()) {
+		if p == pin {
+			return false
+		}
+	}
+
+	repeated, ascending, descending := true, true, true
+	for i := 1; i < len(pin); i++ {
+		diff := int(pin[i]) - int(pin[i-1])
+		repeated = repeated && diff == 0
+		ascending = ascending && diff == 1
+		descending = descending && diff == -1
+	}
+
+	return !repeated && !ascending && !descending
+}
+
+// isNullable is the validation function for validating if the current field is empty, or otherwise satisfies the
+// validation named by the param, which may itself carry a param eg. nullable=min=3.
+func isNullable(ctx context.Context, fl FieldLevel) bool {
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Map:
+		if field.Len() == 0 {
+			return true
+		}
+	case reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+		if field.IsNil() {
+			return true
+		}
+	default:
+		if !field.IsValid() || field.IsZero() {
+			return true
+		}
+	}
+
+	v := fl.(*validate)
+	tag, param, hasParam := strings.Cut(fl.Param(), tagKeySeparator)
+
+	wrapper, ok := v.v.validations[tag]
+	if !ok || tag == nullableTag {
+		panic(strings.TrimSpace(fmt.Sprintf(undefinedValidation, tag, v.cf.name)))
+	}
+
+	// run the named validation as if it were the current tag so that it sees its own param
+	ct := v.ct
+	v.ct = &cTag{tag: tag, aliasTag: tag, param: param, hasParam: hasParam, hasTag: true, fn: wrapper.fn}
+	defer func() { v.ct = ct }()
+
+	return wrapper.fn(ctx, v)
+}
+
+// isPowerOfTwo is the validation function for validating if the current field's value is a positive power of two.
+func isPowerOfTwo(fl FieldLevel) bool {
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := field.Int()
+		return n > 0 && n&(n-1) == 0
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := field.Uint()
+		return n > 0 && n&(n-1) == 0
+	}
+
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// isPowerOf is the validation function for validating if the current field's value is a positive power of the
+// base provided by the param.
+func isPowerOf(fl FieldLevel) bool {
+	field := fl.Field()
+
+	base := asUint(fl.Param())
+	if base < 2 {
+		panic(fmt.Sprintf("Bad param for power_of %s", fl.Param()))
+	}
+
+	var n uint64
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Int() <= 0 {
+			return false
+		}
+		n = uint64(field.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n = field.Uint()
+
+	default:
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	if n == 0 {
+		return false
+	}
+
+	for n%base == 0 {
+		n /= base
+	}
+	return n == 1
+}
+
+// requiredSliceIf is the validation function for validating if the current slice, array or map field has at
+// least one element, only if all the other specified fields are equal to the value following the specified field.
+// Unlike required_if a non nil but empty collection does not satisfy it.
+func requiredSliceIf(fl FieldLevel) bool {
+	params := parseOneOfParam2(fl.Param())
+	if len(params)%2 != 0 {
+		panic(fmt.Sprintf("Bad param number for required_slice_if %s", fl.FieldName()))
+	}
+
+	for i := 0; i < len(params); i += 2 {
+		if !requireCheckFieldValue(fl, params[i], params[i+1], false) {
+			return true
+		}
+	}
+
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return field.Len() > 0
+	case reflect.Ptr:
+		// only reached for nil pointers, which hold no elements
+		return false
+	}
+
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// luhnSeparators are the characters commonly used to group the digits of Luhn checked numbers.
+var luhnSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "/", "")
+
+// hasLuhn is the validation function for validating if the current field's value is a numeric string, optionally
+// grouped by separators, with a valid Luhn check digit.
+func hasLuhn(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	str := luhnSeparators.Replace(field.String())
+	if len(str) < 2 || !numberRegex().MatchString(str) {
+		return false
+	}
+
+	return digitsHaveLuhnChecksum(strings.Split(str, ""))
+}
+
+// isIMEI is the validation function for validating if the current field's value is a valid 15 digit IMEI,
+// 14 digits followed by a Luhn check digit.
+func isIMEI(fl FieldLevel) bool {
+	imei := fl.Field().String()
+
+	if len(imei) != 15 || !numberRegex().MatchString(imei) {
+		return false
+	}
+
+	return digitsHaveLuhnChecksum(strings.Split(imei, ""))
+}
+
+// isIMEISV is the validation function for validating if the current field's value is a valid 16 digit IMEISV,
+// which carries a software version number in place of the IMEI check digit.
+func isIMEISV(fl FieldLevel) bool {
+	imeisv := fl.Field().String()
+
+	return len(imeisv) == 16 && numberRegex().MatchString(imeisv)
+}
+
+// hasMaxGeoPrecision is the validation function for validating if the current field's latitude or longitude value
+// has no more decimal places than provided by the param, ignoring trailing zeros.
+func hasMaxGeoPrecision(fl FieldLevel) bool {
+	field := fl.Field()
+	maxPlaces := asUint(strings.TrimPrefix(fl.Param(), "max="))
+
+	var str string
+
+	switch field.Kind() {
+	case reflect.Float32:
+		str = strconv.FormatFloat(field.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		str = strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	case reflect.String:
+		str = strings.TrimSpace(field.String())
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return false
+		}
+		if strings.ContainsAny(str, "eE") {
+			str = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	default:
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	_, decimals, _ := strings.Cut(str, ".")
+	return uint64(len(strings.TrimRight(decimals, "0"))) <= maxPlaces
+}
+
+// isFKExisting is the validation function for validating if the current field's value exists according to the
+// FKResolverFunc registered under the name provided by the param. Resolver errors and a cancelled context fail
+// validation.
+func isFKExisting(ctx context.Context, fl FieldLevel) bool {
+	v := fl.(*validate)
+
+	resolve, ok := v.v.fkResolvers[fl.Param()]
+	if !ok {
+		panic(fmt.Sprintf("Bad param for fk_exists %s", fl.Param()))
+	}
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	exists, err := resolve(ctx, fl.Field().Interface())
+	return err == nil && exists && ctx.Err() == nil
+}
+
+// hasMaxSliceBytes is the validation function for validating if the combined byte size of the elements of the
+// current []string or [][]byte field is no more than the size provided by the param eg. slice_bytes=max=1MB.
+func hasMaxSliceBytes(fl FieldLevel) bool {
+	field := fl.Field()
+	maxBytes := asByteSize(strings.TrimPrefix(fl.Param(), "max="))
+
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	elem := field.Type().Elem()
+	if elem.Kind() != reflect.String && (elem.Kind() != reflect.Slice || elem.Elem().Kind() != reflect.Uint8) {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	var total uint64
+	for i := 0; i < field.Len(); i++ {
+		total += uint64(field.Index(i).Len())
+		if total > maxBytes {
+			return false
+		}
+	}
+	return true
+}
+
+// isHostPort is the validation function for validating if the current field's value is a hostname or IP address
+// followed by a port in the range 1-65535, with IPv6 addresses enclosed in brackets eg. [::1]:8080.
+func isHostPort(fl FieldLevel) bool {
+	val := fl.Field().String()
+
+	host, port, err := net.SplitHostPort(val)
+	if err != nil || len(host) == 0 {
+		return false
+	}
+
+	if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+		return false
+	}
+
+	// brackets are only valid around IPv6 addresses
+	if strings.HasPrefix(val, "[") {
+		ip := net.ParseIP(host)
+		return ip != nil && ip.To4() == nil
+	}
+
+	return net.ParseIP(host) != nil || hostnameRegexRFC1123().MatchString(host)
+}
+
+// isJSONPointer is the validation function for validating if the current field's value is a valid RFC 6901 JSON Pointer.
+func isJSONPointer(fl FieldLevel) bool {
+	return jsonPointerRegex().MatchString(fl.Field().String())
+}
+
+// hasMaxUnique is the validation function for validating if the number of distinct elements of the current slice,
+// array or map field is no more than the param, duplicates not counting towards the limit. Nil pointer and interface
+// elements all count as the one distinct value and elements holding values which can't be map keys eg. an interface
+// holding a slice are compared using reflect.DeepEqual.
+func hasMaxUnique(fl FieldLevel) bool {
+	field := fl.Field()
+	maxUnique := asInt(fl.Param())
+	v := reflect.ValueOf(struct{}{})
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		elem := field.Type().Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+
+		if !elem.Comparable() {
+			panic(fmt.Sprintf("Bad field type %s", field.Type()))
+		}
+
+		m := reflect.MakeMap(reflect.MapOf(elem, v.Type()))
+		var (
+			hasNil     bool
+			unhashable []interface{}
+		)
+
+		add := func(val reflect.Value) {
+			switch val.Kind() {
+			case reflect.Ptr, reflect.Interface:
+				if val.IsNil() {
+					hasNil = true
+					return
+				}
+			}
+
+			val = reflect.Indirect(val)
+			if val.Comparable() {
+				m.SetMapIndex(val, v)
+				return
+			}
+
+			for _, u := range unhashable {
+				if reflect.DeepEqual(u, val.Interface()) {
+					return
+				}
+			}
+			unhashable = append(unhashable, val.Interface())
+		}
+
+		if field.Kind() == reflect.Map {
+			for _, k := range field.MapKeys() {
+				add(field.MapIndex(k))
+			}
+		} else {
+			for i := 0; i < field.Len(); i++ {
+				add(field.Index(i))
+			}
+		}
+
+		unique := int64(m.Len() + len(unhashable))
+		if hasNil {
+			unique++
+		}
+		return unique <= maxUnique
+	}
+
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// isMoneyNonNeg is the validation function for validating if the current field's value is a non negative decimal
+// amount with at most 2 decimal places.
+func isMoneyNonNeg(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	return moneyNonNegRegex().MatchString(field.String())
+}
+
+// isEqCurrentUser is the validation function for validating if the current field's value equals the ID of the
+// authenticated principal read from the context by the registered PrincipalExtractorFunc. Validation fails when
+// the context holds no principal.
+func isEqCurrentUser(ctx context.Context, fl FieldLevel) bool {
+	extract := fl.(*validate).v.principalExtractor
+	if extract == nil {
+		panic("No principal extractor registered for eq_current_user")
+	}
+
+	id, ok := extract(ctx)
+	if !ok {
+		return false
+	}
+
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String() == id
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10) == id
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10) == id
+	}
+
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+var (
+	alphanumPlusRegexCache       = map[string]*regexp.Regexp{}
+	alphanumPlusRegexCacheRWLock = sync.RWMutex{}
+)
+
+// alphanumPlusRegex returns the compiled regex matching ASCII letters, digits and the characters of extra,
+// caching it per param.
+func alphanumPlusRegex(extra string) *regexp.Regexp {
+	alphanumPlusRegexCacheRWLock.RLock()
+	re, ok := alphanumPlusRegexCache[extra]
+	alphanumPlusRegexCacheRWLock.RUnlock()
+	if !ok {
+		var class strings.Builder
+		for _, r := range extra {
+			// escape all punctuation so characters such as '-', ']' and '^' are taken literally
+			if r < utf8.RuneSelf && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				class.WriteByte('\\')
+			}
+			class.WriteRune(r)
+		}
+		re = regexp.MustCompile("^[a-zA-Z0-9" + class.String() + "]+$")
+
+		alphanumPlusRegexCacheRWLock.Lock()
+		alphanumPlusRegexCache[extra] = re
+		alphanumPlusRegexCacheRWLock.Unlock()
+	}
+	return re
+}
+
+// isAlphanumPlus is the validation function for validating if the current field's value contains only ASCII
+// letters, digits and the extra characters provided by the param.
+func isAlphanumPlus(fl FieldLevel) bool {
+	return alphanumPlusRegex(fl.Param()).MatchString(fl.Field().String())
+}
+
+// spdxExpressionReplacer surrounds parentheses with spaces so an SPDX license expression can be split into tokens.
+var spdxExpressionReplacer = strings.NewReplacer("(", " ( ", ")", " ) ")
+
+// spdxExpression is a recursive descent parser of the tokens of an SPDX license expression.
+type spdxExpression struct {
+	tokens []string
+	pos    int
+}
+
+func (e *spdxExpression) next() string {
+	if e.pos >= len(e.tokens) {
+		return ""
+	}
+	e.pos++
+	return e.tokens[e.pos-1]
+}
+
+func (e *spdxExpression) accept(token string) bool {
+	if e.pos < len(e.tokens) && e.tokens[e.pos] == token {
+		e.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr parses: and-expression { "OR" and-expression }
+func (e *spdxExpression) parseOr() bool {
+	if !e.parseAnd() {
+		return false
+	}
+	for e.accept("OR") {
+		if !e.parseAnd() {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAnd parses: term { "AND" term }
+func (e *spdxExpression) parseAnd() bool {
+	if !e.parseTerm() {
+		return false
+	}
+	for e.accept("AND") {
+		if !e.parseTerm() {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTerm parses: "(" or-expression ")" | license-id [ "+" ] [ "WITH" exception-id ]
+func (e *spdxExpression) parseTerm() bool {
+	if e.accept("(") {
+		return e.parseOr() && e.accept(")")
+	}
+
+	id := e.next()
+	if _, ok := spdxLicenses[strings.TrimSuffix(id, "+")]; !ok && !strings.HasPrefix(id, "LicenseRef-") {
+		return false
+	}
+
+	if e.accept("WITH") {
+		_, ok := spdxExceptions[e.next()]
+		return ok
+	}
+	return true
+}
+
+// isSPDXLicense is the validation function for validating if the current field's value is an SPDX license
+// identifier, or an SPDX license expression combining them such as "MIT OR Apache-2.0".
+func isSPDXLicense(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	e := &spdxExpression{tokens: strings.Fields(spdxExpressionReplacer.Replace(field.String()))}
+	return len(e.tokens) > 0 && e.parseOr() && e.pos == len(e.tokens)
+}
+
+// isEnumRange is the validation function for validating if the current integer field's value is within the
+// inclusive ordinal range provided by the space separated param eg. enum_range=0 2.
+func isEnumRange(fl FieldLevel) bool {
+	params := parseOneOfParam2(fl.Param())
+	if len(params) != 2 {
+		panic(fmt.Sprintf("Bad param for enum_range %s", fl.Param()))
+	}
+
+	lo, hi := asInt(params[0]), asInt(params[1])
+	if lo > hi {
+		panic(fmt.Sprintf("Bad param for enum_range %s", fl.Param()))
+	}
+
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := field.Int()
+		return n >= lo && n <= hi
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := field.Uint()
+		return hi >= 0 && n <= uint64(hi) && (lo <= 0 || n >= uint64(lo))
+	}
+
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// isWithinRateLimit is the validation function for validating if the subject identified by the current field, or
+// the field named by key, has made no more than max attempts within the window according to the registered
+// RateLimiter eg. rate_limit=key=Email max=5 window=1m. Each validation counts as an attempt and store errors fail
+// validation.
+func isWithinRateLimit(ctx context.Context, fl FieldLevel) bool {
+	store := fl.(*validate).v.rateLimiter
+	if store == nil {
+		panic("No rate limiter registered for rate_limit")
+	}
+
+	var (
+		key    string
+		limit  int
+		window time.Duration
+		err    error
+	)
+
+	for _, p := range parseOneOfParam2(fl.Param()) {
+		name, val, _ := strings.Cut(p, "=")
+
+		switch name {
+		case "key":
+			key = val
+		case "max":
+			limit, err = strconv.Atoi(val)
+		case "window":
+			window, err = time.ParseDuration(val)
+		default:
+			panic(fmt.Sprintf("Bad param for rate_limit %s", fl.Param()))
+		}
+		panicIf(err)
+	}
+
+	if limit <= 0 || window <= 0 {
+		panic(fmt.Sprintf("Bad param for rate_limit %s", fl.Param()))
+	}
+
+	subject, name := fl.Field(), fl.StructFieldName()
+	if len(key) > 0 {
+		var found bool
+		if subject, _, _, found = fl.GetStructFieldOKAdvanced2(fl.Parent(), key); !found {
+			panic(fmt.Sprintf("Bad field name %s", key))
+		}
+		name = key
+	}
+
+	allowed, err := store.Allow(ctx, name+":"+fmt.Sprint(subject.Interface()), limit, window)
+	return err == nil && allowed
+}
+
+// phoneSeparators are the characters commonly used to group the digits of phone numbers.
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// phoneLeadingZeroCountries are the countries whose national numbers have no trunk prefix, their leading 0 being
+// kept in E.164 eg. Italian 06 6982 1234 is +39 06 6982 1234.
+var phoneLeadingZeroCountries = map[string]struct{}{"IT": {}, "SM": {}, "VA": {}}
+
+// canonicalPhone returns the E.164 form of the phone number of the ISO 3166-1 alpha-2 country, accepting
+// international numbers prefixed by + or 00 and national numbers with an optional trunk prefix, and whether
+// the number belongs to the country.
+func canonicalPhone(phone, country string) (string, bool) {
+	phone = phoneSeparators.Replace(phone)
+
+	switch {
+	case strings.HasPrefix(phone, "+"):
+	case strings.HasPrefix(phone, "00"):
+		phone = "+" + phone[2:]
+	default:
+		codes := iso3166_1_alpha2_calling_codes[country]
+		if len(codes) == 0 {
+			return "", false
+		}
+
+		code := codes[0]
+		if code[0] == '1' {
+			// national numbers within the North American Numbering Plan include the area code
+			code = "1"
+			if len(phone) == 11 {
+				phone = strings.TrimPrefix(phone, "1")
+			}
+		} else if _, ok := phoneLeadingZeroCountries[country]; !ok {
+			phone = strings.TrimPrefix(phone, "0")
+		}
+		phone = "+" + code + phone
+	}
+
+	// no country's numbers have fewer than 7 digits including the calling code
+	return phone, len(phone) > 7 && phoneMatchesCountry(phone, country)
+}
+
+// isPhoneCanonical is the validation function for validating if the current field's value is a phone number of
+// the country provided by the param and, if so, rewriting the field in place to its canonical E.164 form.
+func isPhoneCanonical(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	if !field.CanSet() {
+		panic(fmt.Sprintf("Field not settable for phone_canonical %s, validate a pointer", fl.FieldName()))
+	}
+
+	canonical, ok := canonicalPhone(field.String(), strings.ToUpper(fl.Param()))
+	if !ok {
+		return false
+	}
+
+	field.SetString(canonical)
+	return true
+}
+
+// isIBAN is the validation function for validating if the current field's value is a valid IBAN, with the length
+// of its country and a valid mod-97 checksum. Spaces are ignored and letters may be lowercase.
+func isIBAN(fl FieldLevel) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(fl.Field().String(), " ", ""))
+
+	if !ibanRegex().MatchString(iban) || ibanCountryLengths[iban[:2]] != len(iban) {
+		return false
+	}
+
+	// move the country code and check digits to the end and interpret letters as 10-35
+	var remainder int
+	for _, c := range iban[4:] + iban[:4] {
+		if c >= 'A' {
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
+
+// vatSeparators are the characters commonly used to group the characters of VAT identification numbers.
+var vatSeparators = strings.NewReplacer(" ", "", "-", "", ".", "")
+
+// isVAT is the validation function for validating if the current field's value has the structure, and where
+// known the checksum, of a VAT identification number of the country provided by the param. The country prefix
+// is optional and separators are ignored.
+func isVAT(fl FieldLevel) bool {
+	format, ok := vatFormats[strings.ToUpper(fl.Param())]
+	if !ok {
+		panic(fmt.Sprintf("Bad param for vat %s", fl.Param()))
+	}
+
+	number := strings.TrimPrefix(strings.ToUpper(vatSeparators.Replace(fl.Field().String())), format.prefix)
+
+	return format.regex().MatchString(number) && (format.checksum == nil || format.checksum(number))
+}
+
+// hasNoBidiControl is the validation function for validating if the current field's value contains no Unicode
+// bidirectional control characters, as used by "Trojan Source" attacks, and no control characters other than
+// tab, line feed and carriage return.
+func hasNoBidiControl(fl FieldLevel) bool {
+	for _, r := range fl.Field().String() {
+		if unicode.Is(unicode.Bidi_Control, r) || (unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r') {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlignedTo is the validation function for validating if the current field's time is exactly on a boundary of
+// the interval provided by the param, counted from midnight in UTC or the optional timezone eg. aligned_to=15m
+// tz=Asia/Kolkata.
+func isAlignedTo(fl FieldLevel) bool {
+	t := asTime(fl.Field())
+	loc := time.UTC
+
+	var interval time.Duration
+	for _, p := range strings.Fields(fl.Param()) {
+		if strings.HasPrefix(p, "tz=") {
+			loc = loadTimeLocation(p[3:])
+			continue
+		}
+
+		var err error
+		interval, err = time.ParseDuration(p)
+		panicIf(err)
+	}
+
+	if interval <= 0 {
+		panic(fmt.Sprintf("Bad param for aligned_to %s", fl.Param()))
+	}
+
+	_, offset := t.In(loc).Zone()
+	local := time.Duration(t.Unix()+int64(offset))*time.Second + time.Duration(t.Nanosecond())
+	return local%interval == 0
+}
+
+// isPostgresDSN reports whether the connection string is a PostgreSQL URL or keyword/value connection
+// string naming both a host and a database.
+func isPostgresDSN(dsn string) bool {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return false
+		}
+
+		host := u.Hostname()
+		if len(host) == 0 {
+			// a host may be provided as a parameter eg. for a unix socket
+			host = u.Query().Get("host")
+		}
+
+		database := strings.TrimPrefix(u.Path, "/")
+		if len(database) == 0 {
+			database = u.Query().Get("dbname")
+		}
+		return len(host) > 0 && len(database) > 0 && !strings.Contains(database, "/")
+	}
+
+	params := make(map[string]string)
+	for rest := strings.TrimSpace(dsn); len(rest) > 0; rest = strings.TrimSpace(rest) {
+		m := postgresDSNKeyValueRegex().FindStringSubmatch(rest)
+		if m == nil {
+			return false
+		}
+		params[m[1]] = strings.Trim(m[2], "'")
+		rest = rest[len(m[0]):]
+	}
+	return len(params["host"]) > 0 && len(params["dbname"]) > 0
+}
+
+// isDSN is the validation function for validating if the current field's value is a connection string for the
+// database driver provided by the param, naming both a host and a database. No connection is made.
+func isDSN(fl FieldLevel) bool {
+	dsn := fl.Field().String()
+
+	switch strings.ToLower(fl.Param()) {
+	case "postgres", "postgresql", "pgx":
+		return isPostgresDSN(dsn)
+	case "mysql":
+		return mysqlDSNRegex().MatchString(dsn)
+	}
+
+	panic(fmt.Sprintf("Bad param for dsn %s", fl.Param()))
+}
+
+// tupleKinds are the element kinds which may be provided to the tuple validation.
+var tupleKinds = map[string]struct{}{
+	"string": {}, "int": {}, "float": {}, "bool": {}, "map": {}, "slice": {}, "nil": {}, "any": {},
+}
+
+// tupleKindMatches reports whether the tuple element is of the kind named by the tuple param. Numbers decoded from
+// JSON are float64, so integral floats are also ints.
+func tupleKindMatches(elem reflect.Value, kind string) bool {
+	if elem.Kind() == reflect.Interface && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+
+	switch kind {
+	case "any":
+		return true
+	case "nil":
+		return !elem.IsValid() || (elem.Kind() == reflect.Interface || elem.Kind() == reflect.Ptr) && elem.IsNil()
+	}
+
+	switch elem.Kind() {
+	case reflect.String:
+		return kind == "string"
+	case reflect.Bool:
+		return kind == "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kind == "int" || kind == "float"
+	case reflect.Float32, reflect.Float64:
+		return kind == "float" || kind == "int" && elem.Float() == math.Trunc(elem.Float()) && !math.IsInf(elem.Float(), 0)
+	case reflect.Map, reflect.Struct:
+		return kind == "map"
+	case reflect.Slice, reflect.Array:
+		return kind == "slice"
+	}
+	return false
+}
+
+// isTuple is the validation function for validating if the current slice or array field has exactly one element
+// per kind provided by the space separated param, each element being of the kind at its position.
+func isTuple(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	kinds := strings.Fields(fl.Param())
+	for _, kind := range kinds {
+		if _, ok := tupleKinds[kind]; !ok {
+			panic(fmt.Sprintf("Bad param for tuple %s", kind))
+		}
+	}
+
+	if field.Len() != len(kinds) {
+		return false
+	}
+
+	for i, kind := range kinds {
+		if !tupleKindMatches(field.Index(i), kind) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDatetimeAny is the validation function for validating if the current field's value is a valid datetime under
+// any of the space separated layouts provided by the param, layouts containing spaces being single quoted.
+func isDatetimeAny(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	for _, layout := range parseOneOfParam2(fl.Param()) {
+		if _, err := time.Parse(layout, field.String()); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMinIncrement is the validation function for validating if the current numeric field's value is at least the
+// value of the field named by the param plus the increment following it eg. min_increment=Current 1.
+func hasMinIncrement(fl FieldLevel) bool {
+	params := parseOneOfParam2(fl.Param())
+	if len(params) != 2 {
+		panic(fmt.Sprintf("Bad param number for min_increment %s", fl.FieldName()))
+	}
+
+	field := fl.Field()
+	kind := field.Kind()
+
+	currentField, currentKind, _, ok := fl.GetStructFieldOKAdvanced2(fl.Parent(), params[0])
+	if !ok || currentKind != kind {
+		return false
+	}
+
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int()-currentField.Int() >= asInt(params[1])
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return field.Uint() >= currentField.Uint() && field.Uint()-currentField.Uint() >= asUint(params[1])
+
+	case reflect.Float32, reflect.Float64:
+		return field.Float() >= currentField.Float()+asFloat64(params[1])
+	}
+
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// isDNSNameStrict is the validation function for validating if the current field's value is a concrete DNS name,
+// as required of a TLS certificate's subject alternative names, meaning no wildcards, underscores or trailing dot.
+func isDNSNameStrict(fl FieldLevel) bool {
+	name := fl.Field().String()
+
+	if len(name) > 253 || !dnsNameStrictRegex().MatchString(name) {
+		return false
+	}
+
+	// an all numeric top level label would be an IPv4 address rather than a name
+	tld := name[strings.LastIndexByte(name, '.')+1:]
+	return !numberRegex().MatchString(tld)
+}
+
+// percentSplitEpsilon is the tolerance of the sum of a percent_split map's float values.
+const percentSplitEpsilon = 1e-3
+
+// isPercentSplit is the validation function for validating if the values of the current map field sum to exactly
+// 100, within a small tolerance for floats. On failure the actual sum is reported as the error's param.
+func isPercentSplit(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.Map {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	var sum string
+	var ok bool
+
+	switch field.Type().Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var total int64
+		for iter := field.MapRange(); iter.Next(); {
+			total += iter.Value().Int()
+		}
+		sum, ok = strconv.FormatInt(total, 10), total == 100
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var total uint64
+		for iter := field.MapRange(); iter.Next(); {
+			total += iter.Value().Uint()
+		}
+		sum, ok = strconv.FormatUint(total, 10), total == 100
+
+	case reflect.Float32, reflect.Float64:
+		var total float64
+		for iter := field.MapRange(); iter.Next(); {
+			total += iter.Value().Float()
+		}
+		// round away accumulated floating point error from the reported sum
+		sum, ok = strconv.FormatFloat(math.Round(total*1e6)/1e6, 'f', -1, 64), math.Abs(total-100) <= percentSplitEpsilon
+
+	default:
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	if !ok {
+		fl.(*validate).reportFailParam(sum)
+	}
+	return ok
+}
+
+// isSequenceGtKey is the validation function for validating if the current integer field's value is greater than the
+// last sequence number stored for the key held by the field named by the param, according to the registered
+// SequenceProviderFunc. Provider errors fail validation.
+func isSequenceGtKey(ctx context.Context, fl FieldLevel) bool {
+	provider := fl.(*validate).v.sequenceProvider
+	if provider == nil {
+		panic("No sequence provider registered for sequence_gt_key")
+	}
+
+	return isGtStoredForKey(ctx, fl, fl.Param(), provider)
+}
+
+// isGtStoredForKey returns whether the current integer field's value is greater than the number returned by the
+// provider for the key held by the field named keyField. Provider errors fail validation.
+func isGtStoredForKey(ctx context.Context, fl FieldLevel, keyField string, provider func(ctx context.Context, key string) (int64, error)) bool {
+	key, _, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), keyField)
+	if !found {
+		panic(fmt.Sprintf("Bad field name %s", keyField))
+	}
+
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	stored, err := provider(ctx, fmt.Sprint(key.Interface()))
+	if err != nil {
+		return false
+	}
+
+	if field.CanInt() {
+		return field.Int() > stored
+	}
+	return stored < 0 || field.Uint() > uint64(stored)
+}
+
+// asFileHeader returns the multipart.FileHeader held by the field, which has been dereferenced from any pointer.
+func asFileHeader(field reflect.Value) *multipart.FileHeader {
+	switch {
+	case field.Type() != reflect.TypeOf(multipart.FileHeader{}):
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	case field.CanAddr():
+		return field.Addr().Interface().(*multipart.FileHeader)
+	}
+	h := field.Interface().(multipart.FileHeader)
+	return &h
+}
+
+// aspectRatioTolerance is the relative difference allowed between an image's aspect ratio and an aspect_ratio param.
+const aspectRatioTolerance = 0.01
+
+// hasAspectRatio is the validation function for validating if the current multipart.FileHeader field is an image,
+// of a format whose decoder the program has registered with the image package, whose width:height ratio matches
+// any of the space separated ratios provided by the param eg. aspect_ratio=16:9 1:1, within a 1% tolerance.
+func hasAspectRatio(fl FieldLevel) bool {
+	fh := asFileHeader(fl.Field())
+
+	var ratios []float64
+	for _, p := range strings.Fields(fl.Param()) {
+		w, h, ok := strings.Cut(p, ":")
+		if !ok {
+			panic(fmt.Sprintf("Bad param for aspect_ratio %s", p))
+		}
+
+		width, errW := strconv.ParseFloat(w, 64)
+		height, errH := strconv.ParseFloat(h, 64)
+		if errW != nil || errH != nil || width <= 0 || height <= 0 {
+			panic(fmt.Sprintf("Bad param for aspect_ratio %s", p))
+		}
+		ratios = append(ratios, width/height)
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	cfg, _, err := image.DecodeConfig(file)
+	if errors.Is(err, image.ErrFormat) {
+		// either not an image or of a format whose decoder the program hasn't registered
+		fl.(*validate).reportFailParam("unknown image format")
+		return false
+	}
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 {
+		fl.(*validate).reportFailParam("undecodable image")
+		return false
+	}
+
+	actual := float64(cfg.Width) / float64(cfg.Height)
+	for _, r := range ratios {
+		if math.Abs(actual-r)/r <= aspectRatioTolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBalancedBrackets is the validation function for validating if the parentheses, square brackets and braces of
+// the current field's value are balanced and properly nested. With the quotes param brackets within single or double
+// quoted strings are ignored and an unterminated quoted string fails validation.
+func hasBalancedBrackets(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	var quotes bool
+	switch fl.Param() {
+	case "":
+	case "quotes":
+		quotes = true
+	default:
+		panic(fmt.Sprintf("Bad param for balanced_brackets %s", fl.Param()))
+	}
+
+	var (
+		stack   []rune
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range field.String() {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == quote:
+				quote = 0
+			}
+			continue
+		}
+
+		switch r {
+		case '\'', '"':
+			if quotes {
+				quote = r
+			}
+		case '(':
+			stack = append(stack, ')')
+		case '[':
+			stack = append(stack, ']')
+		case '{':
+			stack = append(stack, '}')
+		case ')', ']', '}':
+			if len(stack) == 0 || stack[len(stack)-1] != r {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return len(stack) == 0 && quote == 0
+}
+
+// themeJSONKeys are the keys a theme_json object must hold.
+var themeJSONKeys = []string{"primary", "secondary", "background"}
+
+// isThemeJSON is the validation function for validating if the current field's value is a JSON object holding the
+// primary, secondary and background keys, each being a hex color. Other keys are allowed.
+func isThemeJSON(fl FieldLevel) bool {
+	var theme map[string]json.RawMessage
+	if err := json.Unmarshal(jsonFieldBytes(fl.Field()), &theme); err != nil || theme == nil {
+		return false
+	}
+
+	for _, key := range themeJSONKeys {
+		var color string
+		if err := json.Unmarshal(theme[key], &color); err != nil || !hexColorRegex().MatchString(color) {
+			return false
+		}
+	}
+	return true
+}
+
+// isQuantity is the validation function for validating if the current field's value is a non-negative number
+// immediately followed by one of the space separated units of the param, eg. "12kg" with units=kg g lb.
+func isQuantity(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	units := parseOneOfParam2(strings.TrimPrefix(fl.Param(), "units="))
+	if len(units) == 0 {
+		panic(fmt.Sprintf("Bad param for quantity %s", fl.Param()))
+	}
+
+	s := field.String()
+	i := strings.LastIndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }) + 1
+
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		return false
+	}
+
+	for _, unit := range units {
+		if s[i:] == unit {
+			return true
+		}
+	}
+	return false
+}
+
+// isTemplateName is the validation function for validating if the current field's value is one of the template
+// names registered via RegisterTemplateNames.
+func isTemplateName(fl FieldLevel) bool {
+	names := fl.(*validate).v.templateNames.Load()
+	if names == nil {
+		panic("No template names registered for template_name")
+	}
+
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	_, ok := (*names)[field.String()]
+	return ok
+}
+
+// isVersionGtStored is the validation function for validating if the current integer field's value is greater than
+// the version stored for the resource whose ID is held by the field named by the param eg. version_gt_stored=key=ID,
+// according to the registered VersionProviderFunc. Provider errors fail validation.
+func isVersionGtStored(ctx context.Context, fl FieldLevel) bool {
+	provider := fl.(*validate).v.versionProvider
+	if provider == nil {
+		panic("No version provider registered for version_gt_stored")
+	}
+
+	return isGtStoredForKey(ctx, fl, strings.TrimPrefix(fl.Param(), "key="), provider)
+}
+
+// isHMACSig is the validation function for validating if the current field's value is the hex encoded HMAC-SHA256,
+// optionally prefixed by "sha256=", of the raw value stored in the context by ContextWithRawValue under the name of
+// the over param, keyed by the secret registered via RegisterHMACSecret under the name of the secretKey param
+// eg. hmac_sig=secretKey=WebhookSecret over=RawBody. A missing raw value fails validation.
+func isHMACSig(ctx context.Context, fl FieldLevel) bool {
+	var secretName, over string
+
+	for _, p := range parseOneOfParam2(fl.Param()) {
+		name, val, _ := strings.Cut(p, "=")
+
+		switch name {
+		case "secretKey":
+			secretName = val
+		case "over":
+			over = val
+		default:
+			panic(fmt.Sprintf("Bad param for hmac_sig %s", fl.Param()))
+		}
+	}
+
+	if len(secretName) == 0 || len(over) == 0 {
+		panic(fmt.Sprintf("Bad param for hmac_sig %s", fl.Param()))
+	}
+
+	secret, ok := fl.(*validate).v.hmacSecrets[secretName]
+	if !ok {
+		panic(fmt.Sprintf("No HMAC secret registered for hmac_sig %s", secretName))
+	}
+
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	raw, ok := ctx.Value(rawValueKey(over)).([]byte)
+	if !ok {
+		return false
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(field.String(), "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(raw)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// hasMaxUTF16Units is the validation function for validating if the current string field's length, counted in
+// UTF-16 code units as by JavaScript's String.length, is less than or equal to the param's value.
+func hasMaxUTF16Units(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	maxUnits := asUint(fl.Param())
+
+	var units uint64
+	for _, r := range field.String() {
+		units += uint64(utf16.RuneLen(r))
+	}
+	return units <= maxUnits
+}
+
+// reservedDNSLabels are the labels rejected by dns_label=no_reserved.
+var reservedDNSLabels = map[string]struct{}{"www": {}, "api": {}, "admin": {}}
+
+// isDNSLabel is the validation function for validating if the current field's value is a single DNS label of 1 to
+// 63 letters, digits and hyphens not starting or ending with a hyphen. With the no_reserved param the reserved
+// labels www, api and admin are rejected regardless of case.
+func isDNSLabel(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	label := field.String()
+
+	switch fl.Param() {
+	case "":
+	case "no_reserved":
+		if _, ok := reservedDNSLabels[strings.ToLower(label)]; ok {
+			return false
+		}
+	default:
+		panic(fmt.Sprintf("Bad param for dns_label %s", fl.Param()))
+	}
+	return dnsLabelRegex().MatchString(label)
+}
+
+// hasLocalesCover is the validation function for validating if the current map[string]string field holds a
+// non-empty entry for each of the space separated locales provided by the param eg. locales_cover=en zh. On
+// failure the missing locales are reported as the error's param.
+func hasLocalesCover(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	var missing []string
+	for _, locale := range parseOneOfParam2(fl.Param()) {
+		if v := field.MapIndex(reflect.ValueOf(locale).Convert(field.Type().Key())); !v.IsValid() || len(v.String()) == 0 {
+			missing = append(missing, locale)
+		}
+	}
+
+	if len(missing) > 0 {
+		fl.(*validate).reportFailParam(strings.Join(missing, " "))
+		return false
+	}
+	return true
+}
+
+// isFloat32Safe is the validation function for validating if the current float field's value survives conversion to
+// float32 unchanged, as required of values destined for float32 storage or transport.
+func isFloat32Safe(fl FieldLevel) bool {
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Float32:
+		return true
+	case reflect.Float64:
+		v := field.Float()
+		return float64(float32(v)) == v || math.IsNaN(v)
+	}
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// mixedScriptsAllowed are the combinations of scripts no_mixed_scripts accepts within a single string, being those
+// of the Highly Restrictive level of Unicode Technical Standard #39.
+var mixedScriptsAllowed = []map[string]bool{
+	{"Latin": true, "Han": true, "Hiragana": true, "Katakana": true},
+	{"Latin": true, "Han": true, "Bopomofo": true},
+	{"Latin": true, "Han": true, "Hangul": true},
+}
+
+// commonScripts are the scripts looked up first by scriptOf, being those most text is written in.
+var commonScripts = []string{"Latin", "Han", "Cyrillic", "Greek", "Arabic"}
+
+var (
+	scriptTablesInit sync.Once
+	scriptNames      []string
+)
+
+// initScriptTables orders the names of unicode.Scripts with commonScripts first and the rest sorted, so lookups
+// are fast for common text and their result doesn't depend on map iteration order.
+func initScriptTables() {
+	scriptNames = append(scriptNames, commonScripts...)
+
+	rest := make([]string, 0, len(unicode.Scripts))
+	for name := range unicode.Scripts {
+		if !slices.Contains(commonScripts, name) {
+			rest = append(rest, name)
+		}
+	}
+	slices.Sort(rest)
+
+	scriptNames = append(scriptNames, rest...)
+}
+
+// scriptOf returns the name of the Unicode script the rune belongs to, or an empty string if none.
+func scriptOf(r rune) string {
+	scriptTablesInit.Do(initScriptTables)
+
+	for _, name := range scriptNames {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// hasNoMixedScripts is the validation function for validating if the current field's characters are all of a single
+// Unicode script, as mixing eg. Latin and Cyrillic is associated with homoglyph attacks. Common characters such as
+// digits and punctuation belong to any script, and the combinations of mixedScriptsAllowed are accepted.
+func hasNoMixedScripts(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	scripts := make(map[string]bool)
+	var last *unicode.RangeTable
+
+	for _, r := range field.String() {
+		// runs of the same script are the norm so the script of the previous rune is checked first
+		if last != nil && unicode.Is(last, r) {
+			continue
+		}
+
+		if unicode.In(r, unicode.Common, unicode.Inherited) {
+			continue
+		}
+
+		if name := scriptOf(r); len(name) > 0 {
+			scripts[name] = true
+			last = unicode.Scripts[name]
+		}
+	}
+
+	if len(scripts) <= 1 {
+		return true
+	}
+
+ALLOWED:
+	for _, allowed := range mixedScriptsAllowed {
+		for name := range scripts {
+			if !allowed[name] {
+				continue ALLOWED
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isHTTPStatus is the validation function for validating if the current integer field's value is a known HTTP
+// status code, or with the range param simply within 100 to 599.
+func isHTTPStatus(fl FieldLevel) bool {
+	field := fl.Field()
+
+	var code int64
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		code = field.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.Uint() > 599 {
+			return false
+		}
+		code = int64(field.Uint())
+	default:
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	switch fl.Param() {
+	case "":
+		_, ok := httpStatusCodes[code]
+		return ok
+	case "range":
+		return code >= 100 && code <= 599
+	}
+	panic(fmt.Sprintf("Bad param for http_status %s", fl.Param()))
+}
+
+// isProtoEnum is the validation function for validating if the current integer field's value is defined by the
+// protobuf enum registered via RegisterProtoEnum under the param's full name.
+func isProtoEnum(fl FieldLevel) bool {
+	values, ok := fl.(*validate).v.protoEnums[fl.Param()]
+	if !ok {
+		panic(fmt.Sprintf("No proto enum registered for proto_enum %s", fl.Param()))
+	}
+
+	field := fl.Field()
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := field.Int()
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return false
+		}
+		_, ok = values[int32(v)]
+		return ok
+	}
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// hasSumFieldMax is the validation function for validating if the sum of the named numeric field across the
+// structs, or pointers to structs, of the current slice field is less than or equal to the cap provided by the
+// param eg. sum_field_max=Amount 1000. On failure the actual sum is reported as the error's param.
+func hasSumFieldMax(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	params := parseOneOfParam2(fl.Param())
+	if len(params) != 2 {
+		panic(fmt.Sprintf("Bad param number for sum_field_max %s", fl.FieldName()))
+	}
+
+	elemType := field.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	sf, ok := elemType.FieldByName(params[0])
+	if elemType.Kind() != reflect.Struct || !ok {
+		panic(fmt.Sprintf("Bad field name %s", params[0]))
+	}
+
+	var (
+		sum        string
+		intSum     int64
+		uintSum    uint64
+		floatSum   float64
+		withinCaps bool
+	)
+
+	for i := 0; i < field.Len(); i++ {
+		elem := reflect.Indirect(field.Index(i))
+		if !elem.IsValid() {
+			continue
+		}
+
+		amount := elem.FieldByIndex(sf.Index)
+
+		switch sf.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			intSum += amount.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			uintSum += amount.Uint()
+		case reflect.Float32, reflect.Float64:
+			floatSum += amount.Float()
+		default:
+			panic(fmt.Sprintf("Bad field type %s", sf.Type))
+		}
+	}
+
+	switch sf.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sum, withinCaps = strconv.FormatInt(intSum, 10), intSum <= asInt(params[1])
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sum, withinCaps = strconv.FormatUint(uintSum, 10), uintSum <= asUint(params[1])
+	case reflect.Float32, reflect.Float64:
+		// round away accumulated floating point error from the reported sum
+		sum, withinCaps = strconv.FormatFloat(math.Round(floatSum*1e6)/1e6, 'f', -1, 64), floatSum <= asFloat64(params[1])
+	default:
+		panic(fmt.Sprintf("Bad field type %s", sf.Type))
+	}
+
+	if !withinCaps {
+		fl.(*validate).reportFailParam(sum)
+	}
+	return withinCaps
+}
+
+// isLanguageSimple is the validation function for validating if the current field's value is a BCP 47 language tag
+// of only the language or language-REGION forms, without script, variant or extension subtags.
+func isLanguageSimple(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	if !languageSimpleRegex().MatchString(field.String()) {
+		return false
+	}
+
+	_, err := language.Parse(field.String())
+	return err == nil
+}
+
+// isUniqueNonce is the validation function for validating if the current field's value is a nonce not seen before
+// according to the registered NonceStore, which records it. Store errors fail validation.
+func isUniqueNonce(ctx context.Context, fl FieldLevel) bool {
+	store := fl.(*validate).v.nonceStore
+	if store == nil {
+		panic("No nonce store registered for unique_nonce")
+	}
+
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	fresh, err := store.Record(ctx, field.String())
+	return err == nil && fresh
+}
+
+// isBetweenFields is the validation function for validating if the current numeric field's value is inclusively
+// between the values of the two sibling fields named by the param eg. between_fields=Min Max, with numeric strings
+// coerced. When the minimum exceeds the maximum validation fails reporting "inverted" as the error's param, unless
+// the optional swap param eg. between_fields=Min Max swap is provided to accept such bounds swapped.
+func isBetweenFields(fl FieldLevel) bool {
+	params := parseOneOfParam2(fl.Param())
+	if len(params) != 2 && (len(params) != 3 || params[2] != "swap") {
+		panic(fmt.Sprintf("Bad param number for between_fields %s", fl.FieldName()))
+	}
+
+	value, ok := asNumberValue(fl.Field())
+	if !ok {
+		return false
+	}
+
+	var bounds [2]float64
+
+	for i, name := range params[:2] {
+		bound, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), name)
+		if !found {
+			panic(fmt.Sprintf("Bad field name %s", name))
+		}
+
+		if kind == reflect.Ptr || kind == reflect.Interface {
+			return false
+		}
+
+		if bounds[i], ok = asNumberValue(bound); !ok {
+			return false
+		}
+	}
+
+	lo, hi := bounds[0], bounds[1]
+	if lo > hi {
+		if len(params) == 2 {
+			fl.(*validate).reportFailParam("inverted")
+			return false
+		}
+		lo, hi = hi, lo
+	}
+	return value >= lo && value <= hi
+}
+
+// asNumberValue returns the integer, float or numeric string value as a float64, and false for a string which
+// isn't numeric.
+func asNumberValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		return n, err == nil && !math.IsNaN(n)
+	}
+	panic(fmt.Sprintf("Bad field type %s", v.Type()))
+}
+
+// isPercentEncoded is the validation function for validating if the current field's value is strictly percent-encoded,
+// every percent sign being followed by two hex digits and every other character being printable ASCII other than space.
+func isPercentEncoded(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	return percentEncodedRegex().MatchString(field.String())
+}
+
+// isNormalizedTag is the validation function which normalizes the current field's value by trimming it, lowercasing
+// it and replacing runs of whitespace with single hyphens, and validates that the result is a non-empty slug of
+// lowercase letters, digits and single hyphens. When valid the field is rewritten with the normalized tag, so the
+// field must be settable.
+func isNormalizedTag(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	if !field.CanSet() {
+		panic(fmt.Sprintf("Field not settable for normalized_tag %s, validate a pointer", fl.FieldName()))
+	}
+
+	tag := strings.Join(strings.Fields(strings.ToLower(field.String())), "-")
+	if !slugRegex().MatchString(tag) {
+		return false
+	}
+
+	field.SetString(tag)
+	return true
+}
+
+// isSafeArchive is the validation function for validating if the current multipart.FileHeader field is a zip archive
+// of no more than max_entries entries, none of whose paths escape the extraction directory, and whose entries
+// decompress to no more than max_size bytes in total eg. safe_archive=max_entries=1000 max_size=100MB. Entries are
+// decompressed in memory, without extracting to disk, so that headers understating their size can't hide a zip bomb.
+func isSafeArchive(ctx context.Context, fl FieldLevel) bool {
+	fh := asFileHeader(fl.Field())
+
+	var maxEntries, maxSize uint64
+
+	for _, p := range parseOneOfParam2(fl.Param()) {
+		name, val, _ := strings.Cut(p, "=")
+
+		switch name {
+		case "max_entries":
+			maxEntries = asUint(val)
+		case "max_size":
+			maxSize = asByteSize(val)
+		default:
+			panic(fmt.Sprintf("Bad param for safe_archive %s", fl.Param()))
+		}
+	}
+
+	if maxEntries == 0 || maxSize == 0 {
+		panic(fmt.Sprintf("Bad param for safe_archive %s", fl.Param()))
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	archive, err := zip.NewReader(file, fh.Size)
+	if err != nil || uint64(len(archive.File)) > maxEntries {
+		return false
+	}
+
+	remaining := int64(min(maxSize, math.MaxInt64-1))
+
+	for _, entry := range archive.File {
+		if ctx.Err() != nil || !isLocalArchivePath(entry.Name) {
+			return false
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return false
+		}
+
+		n, err := io.Copy(io.Discard, io.LimitReader(rc, remaining+1))
+		_ = rc.Close()
+
+		if err != nil || n > remaining {
+			return false
+		}
+		remaining -= n
+	}
+	return true
+}
+
+// isLocalArchivePath returns whether the archive entry's path, using either slash, stays within the extraction
+// directory.
+func isLocalArchivePath(name string) bool {
+	name = strings.ReplaceAll(name, "\\", "/")
+
+	if len(name) == 0 || path.IsAbs(name) || (len(name) > 1 && name[1] == ':') {
+		return false
+	}
+
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// isPortRange is the validation function for validating if the current field's value is a single port eg. 8080, or
+// an inclusive range of ports eg. 8000-8100, each port being from 1 to 65535 and the low port not exceeding the high.
+func isPortRange(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	lo, hi, isRange := strings.Cut(field.String(), "-")
+	if !isRange {
+		hi = lo
+	}
+
+	low, err := strconv.ParseUint(lo, 10, 16)
+	if err != nil || low == 0 {
+		return false
+	}
+
+	high, err := strconv.ParseUint(hi, 10, 16)
+	return err == nil && low <= high
+}
+
+// isShellSafe is the validation function for validating if the current field's value contains none of the shell
+// metacharacters ; | & ` and newlines, nor the $( command substitution. With the allowlist param only letters, digits
+// and the characters . _ / = : , + @ % - are permitted instead.
+func isShellSafe(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	s := field.String()
+
+	switch fl.Param() {
+	case "":
+		return !strings.ContainsAny(s, ";|&`\n\r") && !strings.Contains(s, "$(")
+	case "allowlist":
+		return shellSafeAllowlistRegex().MatchString(s)
+	}
+	panic(fmt.Sprintf("Bad param for shell_safe %s", fl.Param()))
+}
+
+// jsMaxSafeInteger is JavaScript's Number.MAX_SAFE_INTEGER, 2^53-1.
+const jsMaxSafeInteger = 1<<53 - 1
+
+// hasJSSafeInts is the validation function for validating if the current field's value is valid JSON all of whose
+// integer numbers, at any depth, are within JavaScript's safe integer range of plus or minus 2^53-1.
+func hasJSSafeInts(fl FieldLevel) bool {
+	data := jsonFieldBytes(fl.Field())
+	if !json.Valid(data) {
+		return false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return false
+	}
+	return jsSafeInts(value)
+}
+
+// jsSafeInts returns whether all integer numbers within the decoded JSON value are safe JavaScript integers.
+func jsSafeInts(value interface{}) bool {
+	switch v := value.(type) {
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			// not an integer literal, so parsed as a double either way
+			return true
+		}
+		n, err := v.Int64()
+		return err == nil && n >= -jsMaxSafeInteger && n <= jsMaxSafeInteger
+	case []interface{}:
+		for _, elem := range v {
+			if !jsSafeInts(elem) {
+				return false
+			}
+		}
+	case map[string]interface{}:
+		for _, elem := range v {
+			if !jsSafeInts(elem) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isNamedFormat is the validation function for validating if the current field's value passes any of the validations
+// of the composite format registered via RegisterNamedFormat under the param's name.
+func isNamedFormat(ctx context.Context, fl FieldLevel) bool {
+	v := fl.(*validate)
+
+	formats, ok := v.v.namedFormats[fl.Param()]
+	if !ok {
+		panic(fmt.Sprintf("No named format registered for named_format %s", fl.Param()))
+	}
+
+	// run each format's validation as if it were the current tag so that it sees its own param
+	ct := v.ct
+	defer func() { v.ct = ct }()
+
+	for _, format := range formats {
+		v.ct = format
+		if format.fn(ctx, v) {
+			return true
+		}
+	}
+
+	// report the named format's own param rather than one reported by a failed format
+	v.failParams = v.failParams[:0]
+	return false
+}
+
+// hasMaxRepeat is the validation function for validating if the substring provided by the param occurs no more than
+// the param's count of times within the current field's value eg. max_repeat=@ 1.
+func hasMaxRepeat(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	params := parseOneOfParam2(fl.Param())
+	if len(params) != 2 || len(params[0]) == 0 {
+		panic(fmt.Sprintf("Bad param number for max_repeat %s", fl.FieldName()))
+	}
+
+	return uint64(strings.Count(field.String(), params[0])) <= asUint(params[1])
+}
+
+// isPhoneExt is the validation function for validating if the current field's value is an E.164 phone number, whose
+// digits may be grouped by spaces, hyphens, dots and parentheses, optionally followed by an extension of 1 to 10
+// digits introduced by "ext.", "ext", "extension", "x" or "#" eg. "+1 555 123 4567 ext. 1234".
+func isPhoneExt(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	phone := strings.TrimSpace(field.String())
+
+	if m := phoneExtRegex().FindStringSubmatch(phone); m != nil {
+		if len(m[2]) == 0 {
+			return false
+		}
+		phone = m[1]
+	}
+	return e164Regex().MatchString(phoneSeparators.Replace(phone))
+}
+
+// isEmailRFCLimits is the validation function for validating if the current field's value is a valid email address
+// within the RFC 5321 limits of 64 octets for the local part and 254 octets in total.
+func isEmailRFCLimits(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	email := field.String()
+
+	at := strings.LastIndexByte(email, '@')
+	return at >= 0 && at <= 64 && len(email) <= 254 && isEmail(fl)
+}
+
+// crockford32Symbols are the Crockford base32 symbols in value order, followed by the additional check symbols.
+const crockford32Symbols = "0123456789ABCDEFGHJKMNPQRSTVWXYZ*~$=U"
+
+// isCrockford32 is the validation function for validating if the current field's value is a non-empty Crockford base32
+// string, regardless of case and ignoring hyphens. With the check param the final character must be the mod 37 check
+// symbol of the preceding value.
+func isCrockford32(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	code := strings.ToUpper(strings.ReplaceAll(field.String(), "-", ""))
+
+	var check byte
+	switch fl.Param() {
+	case "":
+	case "check":
+		if len(code) < 2 {
+			return false
+		}
+		check, code = code[len(code)-1], code[:len(code)-1]
+	default:
+		panic(fmt.Sprintf("Bad param for crockford32 %s", fl.Param()))
+	}
+
+	if len(code) == 0 {
+		return false
+	}
+
+	var remainder int
+	for i := 0; i < len(code); i++ {
+		v := strings.IndexByte(crockford32Symbols[:32], code[i])
+		if v < 0 {
+			return false
+		}
+		remainder = (remainder*32 + v) % 37
+	}
+	return check == 0 || crockford32Symbols[remainder] == check
+}
+
+// mod11DefaultWeights are the weights used by mod11 when none are provided by the param.
+var mod11DefaultWeights = []int{2, 3, 4, 5, 6, 7}
+
+// hasMod11 is the validation function for validating if the current field's value is a string of digits whose last
+// digit, or X for a check value of 10, is the mod 11 check digit of the preceding digits. The space separated weights
+// of the param, defaulting to 2 3 4 5 6 7, apply to the digits from the rightmost leftwards, repeating as needed.
+func hasMod11(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	weights := mod11DefaultWeights
+	if params := parseOneOfParam2(fl.Param()); len(params) > 0 {
+		weights = make([]int, len(params))
+		for i, p := range params {
+			weights[i] = int(asInt(p))
+		}
+	}
+
+	code := field.String()
+	if len(code) < 2 {
+		return false
+	}
+
+	var sum int
+	for i := len(code) - 2; i >= 0; i-- {
+		d := code[i]
+		if d < '0' || d > '9' {
+			return false
+		}
+		sum += int(d-'0') * weights[(len(code)-2-i)%len(weights)]
+	}
+
+	check := (11 - sum%11) % 11
+
+	switch last := code[len(code)-1]; {
+	case last == 'X' || last == 'x':
+		return check == 10
+	case last >= '0' && last <= '9':
+		return check == int(last-'0')
+	}
+	return false
+}
+
+// couponCharsets are the charsets of coupon_code, in code point order for the Luhn mod N check.
+var couponCharsets = map[string]string{
+	"digits":    "0123456789",
+	"alnum":     "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"crockford": crockford32Symbols[:32],
+}
+
+// isCouponCode is the validation function for validating if the current field's value is a coupon code of hyphen
+// separated segments eg. XXXX-XXXX-XXXX, regardless of case, whose final character is a Luhn mod N check character
+// of the others. The segments, length and charset params configure the number of segments, characters per segment
+// and charset, one of digits, alnum or crockford, defaulting to coupon_code=segments=3 length=4 charset=alnum.
+func isCouponCode(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	segments, length, charset := 3, 4, couponCharsets["alnum"]
+
+	for _, p := range parseOneOfParam2(fl.Param()) {
+		name, val, _ := strings.Cut(p, "=")
+
+		var ok bool
+		switch name {
+		case "segments":
+			segments = int(asInt(val))
+		case "length":
+			length = int(asInt(val))
+		case "charset":
+			if charset, ok = couponCharsets[val]; !ok {
+				panic(fmt.Sprintf("Bad param for coupon_code %s", fl.Param()))
+			}
+		default:
+			panic(fmt.Sprintf("Bad param for coupon_code %s", fl.Param()))
+		}
+	}
+
+	if segments < 1 || length < 1 {
+		panic(fmt.Sprintf("Bad param for coupon_code %s", fl.Param()))
+	}
+
+	parts := strings.Split(strings.ToUpper(field.String()), "-")
+	if len(parts) != segments {
+		return false
+	}
+
+	for _, part := range parts {
+		if len(part) != length {
+			return false
+		}
+	}
+	return hasLuhnModN(strings.Join(parts, ""), charset)
+}
+
+// hasLuhnModN returns whether the code, of characters from the charset, ends with its Luhn mod N check character,
+// N being the size of the charset.
+func hasLuhnModN(code, charset string) bool {
+	n := len(charset)
+
+	var sum int
+	double := false
+
+	for i := len(code) - 1; i >= 0; i-- {
+		v := strings.IndexByte(charset, code[i])
+		if v < 0 {
+			return false
+		}
+
+		if double {
+			v *= 2
+			v = v/n + v%n
+		}
+		sum += v
+		double = !double
+	}
+	return sum%n == 0
+}
+
+// isContentLengthEq is the validation function for validating if the request content length stored in the context by
+// ContextWithContentLength equals the declared size held by the integer field named by the param, or the current
+// field when there is no param. An unknown or missing content length fails validation.
+func isContentLengthEq(ctx context.Context, fl FieldLevel) bool {
+	length, ok := ctx.Value(contentLengthKey{}).(int64)
+
+	size, kind := fl.Field(), fl.Field().Kind()
+	if len(fl.Param()) > 0 {
+		var found bool
+		if size, kind, _, found = fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param()); !found {
+			panic(fmt.Sprintf("Bad field name %s", fl.Param()))
+		}
+	}
+
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return ok && length >= 0 && size.Int() == length
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ok && length >= 0 && size.Uint() == uint64(length)
+	case reflect.Ptr, reflect.Interface:
+		return false
+	}
+	panic(fmt.Sprintf("Bad field type %s", size.Type()))
+}
+
+// isFeatureFlag is the validation function for validating if the current field's value is one of the feature flag
+// keys registered via RegisterFeatureFlags.
+func isFeatureFlag(fl FieldLevel) bool {
+	flags := fl.(*validate).v.featureFlags.Load()
+	if flags == nil {
+		panic("No feature flags registered for feature_flag")
+	}
+
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	_, ok := (*flags)[field.String()]
+	return ok
+}
+
+// isISO8601Duration is the validation function for validating if the current field's value is an ISO 8601 duration
+// eg. P1Y2M10DT2H30M, PT45M or P2W, with at least one component and a fraction allowed on the seconds only.
+func isISO8601Duration(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	d := field.String()
+	return d != "P" && !strings.HasSuffix(d, "T") && iso8601DurationRegex().MatchString(d)
+}
+
+// isAvatarURL is the validation function for validating if the current field's value is an https URL on one of the
+// hosts of the host param whose path has one of the extensions of the ext param, regardless of case,
+// eg. avatar_url=host=cdn.example.com ext=png jpg webp. Space separated values following a param belong to it.
+func isAvatarURL(fl FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+
+	var hosts, exts []string
+	var values *[]string
+
+	for _, p := range parseOneOfParam2(fl.Param()) {
+		name, val, ok := strings.Cut(p, "=")
+		if !ok {
+			if values == nil {
+				panic(fmt.Sprintf("Bad param for avatar_url %s", fl.Param()))
+			}
+			*values = append(*values, p)
+			continue
+		}
+
+		switch name {
+		case "host":
+			values = &hosts
+		case "ext":
+			values = &exts
+		default:
+			panic(fmt.Sprintf("Bad param for avatar_url %s", fl.Param()))
+		}
+		*values = append(*values, val)
+	}
+
+	if len(hosts) == 0 || len(exts) == 0 {
+		panic(fmt.Sprintf("Bad param for avatar_url %s", fl.Param()))
+	}
+
+	u, err := url.Parse(field.String())
+	if err != nil || u.Scheme != "https" || u.User != nil || len(u.Port()) > 0 {
+		return false
+	}
+
+	var hostOK bool
+	for _, host := range hosts {
+		hostOK = hostOK || strings.EqualFold(u.Hostname(), host)
+	}
+	if !hostOK {
+		return false
+	}
+
+	ext := strings.TrimPrefix(path.Ext(u.Path), ".")
+	for _, e := range exts {
+		if len(ext) > 0 && strings.EqualFold(ext, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSONSchema is the validation function for validating if the current field's value is a JSON document valid
+// against the draft-07 JSON Schema document registered under the name of the param, reporting each violation as
+// its own error whose param is the JSON pointer to the violating value and the reason eg. "#/price: expected number
+// but got string".
+func isJSONSchema(fl FieldLevel) bool {
+	v := fl.(*validate)
+
+	schema, ok := v.v.jsonSchemas[fl.Param()]
+	if !ok {
+		panic(fmt.Sprintf("No JSON schema registered for jsonschema %s", fl.Param()))
+	}
+
+	var instance interface{}
+	if err := json.Unmarshal(jsonFieldBytes(fl.Field()), &instance); err != nil {
+		v.reportFailParam("#: invalid JSON")
+		return false
+	}
+
+	violations := validateJSONSchema(schema, instance)
+	for _, violation := range violations {
+		v.reportFailParam(violation)
+	}
+	return len(violations) == 0
+}
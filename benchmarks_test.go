@@ -4,6 +4,7 @@ import (
 	"bytes"
 	sql "database/sql/driver"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -1134,3 +1135,23 @@ func BenchmarkValidateFnParallel(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkNoMixedScriptsLatin(b *testing.B) {
+	validate := New()
+	s := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Var(s, "no_mixed_scripts")
+	}
+}
+
+func BenchmarkNoMixedScriptsUncommon(b *testing.B) {
+	validate := New()
+	s := strings.Repeat("\u1000\u1001\u1002\u1003 \u1004\u1005 ", 20) // Myanmar
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Var(s, "no_mixed_scripts")
+	}
+}
@@ -16,6 +16,30 @@ const (
 // ValidationErrorsTranslations is the translation return type
 type ValidationErrorsTranslations map[string]string
 
+// Severity is the severity level of a failed validation, allowing clients to
+// render errors, warnings and informational messages differently.
+type Severity uint8
+
+// Severity levels, SeverityError being the default for all validations
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// String returns the name of the severity level
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return fmt.Sprintf("Severity(%d)", uint8(s))
+}
+
 // InvalidValidationError describes an invalid argument passed to
 // `Struct`, `StructExcept`, StructPartial` or `Field`
 type InvalidValidationError struct {
@@ -144,6 +168,10 @@ type FieldError interface {
 	// eg. time.Time's type is time.Time
 	Type() reflect.Type
 
+	// Severity returns the severity the failed validation was registered with,
+	// SeverityError unless registered using RegisterValidationWithSeverity.
+	Severity() Severity
+
 	// Translate returns the FieldError's translated error
 	// from the provided 'ut.Translator' and registered 'TranslationFunc'
 	//
@@ -174,6 +202,7 @@ type fieldError struct {
 	param          string
 	kind           reflect.Kind
 	typ            reflect.Type
+	severity       Severity
 }
 
 // Tag returns the validation tag that failed.
@@ -243,6 +272,11 @@ func (fe *fieldError) Type() reflect.Type {
 	return fe.typ
 }
 
+// Severity returns the severity of the failed validation
+func (fe *fieldError) Severity() Severity {
+	return fe.severity
+}
+
 // Error returns the fieldError's error message
 func (fe *fieldError) Error() string {
 	return fmt.Sprintf(fieldErrMsg, fe.ns, fe.Field(), fe.tag)
@@ -0,0 +1,21 @@
+package validator
+
+var httpStatusCodes = map[int64]struct{}{
+	// see: https://www.iana.org/assignments/http-status-codes, along with 418 I'm a teapot
+
+	// informational
+	100: {}, 101: {}, 102: {}, 103: {},
+
+	// success
+	200: {}, 201: {}, 202: {}, 203: {}, 204: {}, 205: {}, 206: {}, 207: {}, 208: {}, 226: {},
+
+	// redirection
+	300: {}, 301: {}, 302: {}, 303: {}, 304: {}, 305: {}, 307: {}, 308: {},
+
+	// client error
+	400: {}, 401: {}, 402: {}, 403: {}, 404: {}, 405: {}, 406: {}, 407: {}, 408: {}, 409: {}, 410: {}, 411: {}, 412: {}, 413: {}, 414: {}, 415: {}, 416: {}, 417: {}, 418: {},
+	421: {}, 422: {}, 423: {}, 424: {}, 425: {}, 426: {}, 428: {}, 429: {}, 431: {}, 451: {},
+
+	// server error
+	500: {}, 501: {}, 502: {}, 503: {}, 504: {}, 505: {}, 506: {}, 507: {}, 508: {}, 510: {}, 511: {},
+}
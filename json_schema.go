@@ -0,0 +1,649 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchemaMaxDepth bounds the nesting of subschemas, and so $ref cycles which don't descend into the instance.
+const jsonSchemaMaxDepth = 64
+
+// jsonSchemaDocument is a decoded draft-07 JSON Schema document whose keywords have been checked, along with its
+// compiled pattern and patternProperties regular expressions.
+type jsonSchemaDocument struct {
+	root    interface{}
+	regexes map[string]*regexp.Regexp
+	refs    map[string]struct{} // $ref targets already checked, so that cycles are checked once
+}
+
+// jsonSchemaValidator validates instances against a decoded draft-07 JSON Schema document, collecting a
+// "<JSON pointer>: <message>" error for each violation, the pointer being in URI fragment form eg. "#/items/0".
+type jsonSchemaValidator struct {
+	doc   *jsonSchemaDocument
+	depth int
+	errs  []string
+}
+
+// validateJSONSchema validates the decoded instance against the schema document and returns the violations.
+func validateJSONSchema(doc *jsonSchemaDocument, instance interface{}) []string {
+	s := &jsonSchemaValidator{doc: doc}
+	s.validate(doc.root, instance, "#")
+	return s.errs
+}
+
+func (s *jsonSchemaValidator) errorf(ptr, format string, args ...interface{}) {
+	s.errs = append(s.errs, ptr+": "+fmt.Sprintf(format, args...))
+}
+
+// valid returns whether the instance is valid against the subschema, without recording any violations.
+func (s *jsonSchemaValidator) valid(schema, instance interface{}, ptr string) bool {
+	sub := &jsonSchemaValidator{doc: s.doc, depth: s.depth}
+	sub.validate(schema, instance, ptr)
+	return len(sub.errs) == 0
+}
+
+func (s *jsonSchemaValidator) validate(schema, instance interface{}, ptr string) {
+	if s.depth++; s.depth > jsonSchemaMaxDepth {
+		s.errorf(ptr, "schema nesting exceeds %d", jsonSchemaMaxDepth)
+		return
+	}
+	defer func() { s.depth-- }()
+
+	switch sch := schema.(type) {
+	case bool:
+		if !sch {
+			s.errorf(ptr, "no value is allowed")
+		}
+		return
+	case map[string]interface{}:
+		if ref, ok := sch["$ref"].(string); ok {
+			// as of draft-07 all other keywords alongside $ref are ignored
+			target, ok := resolveJSONSchemaRef(s.doc.root, ref)
+			if !ok {
+				s.errorf(ptr, "unresolvable $ref %s", ref)
+				return
+			}
+			s.validate(target, instance, ptr)
+			return
+		}
+
+		s.validateGeneric(sch, instance, ptr)
+
+		switch inst := instance.(type) {
+		case float64:
+			s.validateNumber(sch, inst, ptr)
+		case string:
+			s.validateString(sch, inst, ptr)
+		case []interface{}:
+			s.validateArray(sch, inst, ptr)
+		case map[string]interface{}:
+			s.validateObject(sch, inst, ptr)
+		}
+	}
+}
+
+// resolveJSONSchemaRef resolves a JSON pointer reference within the document, eg. "#/definitions/price".
+func resolveJSONSchemaRef(root interface{}, ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+
+	current := root
+	if ref == "#" {
+		return current, true
+	}
+
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch c := current.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if current, ok = c[token]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			current = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func (s *jsonSchemaValidator) validateGeneric(sch map[string]interface{}, instance interface{}, ptr string) {
+	switch t := sch["type"].(type) {
+	case string:
+		if !jsonSchemaHasType(instance, t) {
+			s.errorf(ptr, "expected %s but got %s", t, jsonSchemaTypeOf(instance))
+		}
+	case []interface{}:
+		var ok bool
+		names := make([]string, 0, len(t))
+		for _, name := range t {
+			n, _ := name.(string)
+			ok = ok || jsonSchemaHasType(instance, n)
+			names = append(names, n)
+		}
+		if !ok {
+			s.errorf(ptr, "expected %s but got %s", strings.Join(names, " or "), jsonSchemaTypeOf(instance))
+		}
+	}
+
+	if enum, ok := sch["enum"].([]interface{}); ok {
+		var found bool
+		for _, e := range enum {
+			found = found || reflect.DeepEqual(e, instance)
+		}
+		if !found {
+			s.errorf(ptr, "must be one of the enum values")
+		}
+	}
+
+	if c, ok := sch["const"]; ok && !reflect.DeepEqual(c, instance) {
+		s.errorf(ptr, "must be the const value")
+	}
+
+	if all, ok := sch["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			s.validate(sub, instance, ptr)
+		}
+	}
+
+	if anyOf, ok := sch["anyOf"].([]interface{}); ok {
+		var matched bool
+		for _, sub := range anyOf {
+			if matched = s.valid(sub, instance, ptr); matched {
+				break
+			}
+		}
+		if !matched {
+			s.errorf(ptr, "must match at least one anyOf schema")
+		}
+	}
+
+	if oneOf, ok := sch["oneOf"].([]interface{}); ok {
+		var matches int
+		for _, sub := range oneOf {
+			if s.valid(sub, instance, ptr) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			s.errorf(ptr, "must match exactly one oneOf schema but matched %d", matches)
+		}
+	}
+
+	if not, ok := sch["not"]; ok && s.valid(not, instance, ptr) {
+		s.errorf(ptr, "must not match the not schema")
+	}
+
+	if cond, ok := sch["if"]; ok {
+		if s.valid(cond, instance, ptr) {
+			if then, ok := sch["then"]; ok {
+				s.validate(then, instance, ptr)
+			}
+		} else if els, ok := sch["else"]; ok {
+			s.validate(els, instance, ptr)
+		}
+	}
+}
+
+func (s *jsonSchemaValidator) validateNumber(sch map[string]interface{}, n float64, ptr string) {
+	if m, ok := sch["multipleOf"].(float64); ok && m > 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			s.errorf(ptr, "must be a multiple of %v", m)
+		}
+	}
+
+	if limit, ok := sch["maximum"].(float64); ok && n > limit {
+		s.errorf(ptr, "must be at most %v", limit)
+	}
+
+	if limit, ok := sch["exclusiveMaximum"].(float64); ok && n >= limit {
+		s.errorf(ptr, "must be less than %v", limit)
+	}
+
+	if limit, ok := sch["minimum"].(float64); ok && n < limit {
+		s.errorf(ptr, "must be at least %v", limit)
+	}
+
+	if limit, ok := sch["exclusiveMinimum"].(float64); ok && n <= limit {
+		s.errorf(ptr, "must be greater than %v", limit)
+	}
+}
+
+func (s *jsonSchemaValidator) validateString(sch map[string]interface{}, str string, ptr string) {
+	length := float64(utf8.RuneCountInString(str))
+
+	if limit, ok := sch["maxLength"].(float64); ok && length > limit {
+		s.errorf(ptr, "length must be at most %v", limit)
+	}
+
+	if limit, ok := sch["minLength"].(float64); ok && length < limit {
+		s.errorf(ptr, "length must be at least %v", limit)
+	}
+
+	if pattern, ok := sch["pattern"].(string); ok {
+		if re, ok := s.doc.regexes[pattern]; !ok {
+			s.errorf(ptr, "pattern %s was not compiled", pattern)
+		} else if !re.MatchString(str) {
+			s.errorf(ptr, "must match pattern %s", pattern)
+		}
+	}
+}
+
+func (s *jsonSchemaValidator) validateArray(sch map[string]interface{}, arr []interface{}, ptr string) {
+	switch items := sch["items"].(type) {
+	case []interface{}:
+		for i, elem := range arr {
+			if i < len(items) {
+				s.validate(items[i], elem, ptr+"/"+strconv.Itoa(i))
+			} else if additional, ok := sch["additionalItems"]; ok {
+				s.validate(additional, elem, ptr+"/"+strconv.Itoa(i))
+			}
+		}
+	case map[string]interface{}, bool:
+		for i, elem := range arr {
+			s.validate(items, elem, ptr+"/"+strconv.Itoa(i))
+		}
+	}
+
+	if limit, ok := sch["maxItems"].(float64); ok && float64(len(arr)) > limit {
+		s.errorf(ptr, "must have at most %v items", limit)
+	}
+
+	if limit, ok := sch["minItems"].(float64); ok && float64(len(arr)) < limit {
+		s.errorf(ptr, "must have at least %v items", limit)
+	}
+
+	if unique, _ := sch["uniqueItems"].(bool); unique {
+	UNIQUE:
+		for i := range arr {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(arr[i], arr[j]) {
+					s.errorf(ptr, "items must be unique but %d and %d are equal", j, i)
+					break UNIQUE
+				}
+			}
+		}
+	}
+
+	if contains, ok := sch["contains"]; ok {
+		var found bool
+		for i, elem := range arr {
+			if found = s.valid(contains, elem, ptr+"/"+strconv.Itoa(i)); found {
+				break
+			}
+		}
+		if !found {
+			s.errorf(ptr, "must contain an item matching the contains schema")
+		}
+	}
+}
+
+func (s *jsonSchemaValidator) validateObject(sch map[string]interface{}, obj map[string]interface{}, ptr string) {
+	// validate in key order so that the violations are reported deterministically
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if required, ok := sch["required"].([]interface{}); ok {
+		for _, r := range required {
+			if name, _ := r.(string); !jsonSchemaHasKey(obj, name) {
+				s.errorf(ptr+"/"+jsonPointerEscape(name), "required property is missing")
+			}
+		}
+	}
+
+	properties, _ := sch["properties"].(map[string]interface{})
+	patternProperties, _ := sch["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := sch["additionalProperties"]
+
+	for _, key := range keys {
+		childPtr := ptr + "/" + jsonPointerEscape(key)
+		matched := false
+
+		if prop, ok := properties[key]; ok {
+			s.validate(prop, obj[key], childPtr)
+			matched = true
+		}
+
+		for pattern, prop := range patternProperties {
+			if re, ok := s.doc.regexes[pattern]; !ok {
+				s.errorf(childPtr, "pattern %s was not compiled", pattern)
+			} else if re.MatchString(key) {
+				s.validate(prop, obj[key], childPtr)
+				matched = true
+			}
+		}
+
+		if !matched && hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				s.errorf(childPtr, "additional property is not allowed")
+			} else {
+				s.validate(additional, obj[key], childPtr)
+			}
+		}
+
+		if names, ok := sch["propertyNames"]; ok && !s.valid(names, key, childPtr) {
+			s.errorf(childPtr, "property name must match the propertyNames schema")
+		}
+	}
+
+	if limit, ok := sch["maxProperties"].(float64); ok && float64(len(obj)) > limit {
+		s.errorf(ptr, "must have at most %v properties", limit)
+	}
+
+	if limit, ok := sch["minProperties"].(float64); ok && float64(len(obj)) < limit {
+		s.errorf(ptr, "must have at least %v properties", limit)
+	}
+
+	if dependencies, ok := sch["dependencies"].(map[string]interface{}); ok {
+		for _, key := range keys {
+			switch dep := dependencies[key].(type) {
+			case []interface{}:
+				for _, d := range dep {
+					if name, _ := d.(string); !jsonSchemaHasKey(obj, name) {
+						s.errorf(ptr+"/"+jsonPointerEscape(name), "property is required by %s", key)
+					}
+				}
+			case map[string]interface{}, bool:
+				s.validate(dep, obj, ptr)
+			}
+		}
+	}
+}
+
+func jsonSchemaHasKey(obj map[string]interface{}, key string) bool {
+	_, ok := obj[key]
+	return ok
+}
+
+// jsonSchemaHasType returns whether the decoded instance is of the JSON Schema type.
+func jsonSchemaHasType(instance interface{}, typ string) bool {
+	switch typ {
+	case "integer":
+		n, ok := instance.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := instance.(float64)
+		return ok
+	}
+	return jsonSchemaTypeOf(instance) == typ
+}
+
+// jsonSchemaTypeOf returns the JSON Schema type name of the decoded instance.
+func jsonSchemaTypeOf(instance interface{}) string {
+	switch instance.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+// jsonPointerEscape escapes the reference token for use within a JSON pointer.
+func jsonPointerEscape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// parseJSONSchemaDocument decodes the schema document, which must be a JSON object or boolean, checking the
+// types of the keywords of it and its subschemas and compiling their regular expressions.
+func parseJSONSchemaDocument(schema []byte) (*jsonSchemaDocument, error) {
+	var root interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, err
+	}
+
+	doc := &jsonSchemaDocument{
+		root:    root,
+		regexes: make(map[string]*regexp.Regexp),
+		refs:    make(map[string]struct{}),
+	}
+	if err := doc.check(root, "#"); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// check checks the keywords of the subschema found at the JSON pointer ptr.
+func (doc *jsonSchemaDocument) check(schema interface{}, ptr string) error {
+	sch, ok := schema.(map[string]interface{})
+	if !ok {
+		if _, ok := schema.(bool); ok {
+			return nil
+		}
+		return fmt.Errorf("invalid JSON Schema at %s: schema must be an object or boolean, not %s", ptr, jsonSchemaTypeOf(schema))
+	}
+
+	// check in keyword order so that the first problem found is deterministic
+	keywords := make([]string, 0, len(sch))
+	for keyword := range sch {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	for _, keyword := range keywords {
+		if err := doc.checkKeyword(keyword, sch[keyword], ptr+"/"+jsonPointerEscape(keyword)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (doc *jsonSchemaDocument) checkKeyword(keyword string, value interface{}, ptr string) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("invalid JSON Schema at %s: %s", ptr, fmt.Sprintf(format, args...))
+	}
+
+	switch keyword {
+	case "$ref":
+		ref, ok := value.(string)
+		if !ok {
+			return invalid("$ref must be a string")
+		}
+		target, ok := resolveJSONSchemaRef(doc.root, ref)
+		if !ok {
+			return invalid("unresolvable $ref %s, only references within the document are supported", ref)
+		}
+
+		// the target may lie outside of any keyword, eg. under an unknown one, so isn't necessarily checked otherwise
+		if _, checked := doc.refs[ref]; !checked {
+			doc.refs[ref] = struct{}{}
+			return doc.check(target, ref)
+		}
+
+	case "type":
+		switch t := value.(type) {
+		case string:
+			if !jsonSchemaIsTypeName(t) {
+				return invalid("unknown type %s", t)
+			}
+		case []interface{}:
+			for _, name := range t {
+				if n, ok := name.(string); !ok || !jsonSchemaIsTypeName(n) {
+					return invalid("unknown type %v", name)
+				}
+			}
+		default:
+			return invalid("type must be a string or an array of strings")
+		}
+
+	case "enum":
+		if _, ok := value.([]interface{}); !ok {
+			return invalid("enum must be an array")
+		}
+
+	case "multipleOf":
+		if n, ok := value.(float64); !ok || n <= 0 {
+			return invalid("multipleOf must be a number greater than 0")
+		}
+
+	case "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum":
+		if _, ok := value.(float64); !ok {
+			return invalid("%s must be a number", keyword)
+		}
+
+	case "maxLength", "minLength", "maxItems", "minItems", "maxProperties", "minProperties":
+		if n, ok := value.(float64); !ok || n < 0 || n != math.Trunc(n) {
+			return invalid("%s must be a non-negative integer", keyword)
+		}
+
+	case "uniqueItems":
+		if _, ok := value.(bool); !ok {
+			return invalid("uniqueItems must be a boolean")
+		}
+
+	case "pattern":
+		pattern, ok := value.(string)
+		if !ok {
+			return invalid("pattern must be a string")
+		}
+		return doc.compile(pattern, invalid)
+
+	case "required":
+		names, ok := value.([]interface{})
+		if !ok {
+			return invalid("required must be an array of strings")
+		}
+		for _, name := range names {
+			if _, ok := name.(string); !ok {
+				return invalid("required must be an array of strings")
+			}
+		}
+
+	case "items":
+		if items, ok := value.([]interface{}); ok {
+			return doc.checkAll(items, ptr)
+		}
+		return doc.check(value, ptr)
+
+	case "additionalItems", "contains", "additionalProperties", "propertyNames", "not", "if", "then", "else":
+		return doc.check(value, ptr)
+
+	case "allOf", "anyOf", "oneOf":
+		schemas, ok := value.([]interface{})
+		if !ok || len(schemas) == 0 {
+			return invalid("%s must be a non-empty array of schemas", keyword)
+		}
+		return doc.checkAll(schemas, ptr)
+
+	case "properties", "definitions":
+		props, ok := value.(map[string]interface{})
+		if !ok {
+			return invalid("%s must be an object of schemas", keyword)
+		}
+		return doc.checkEach(props, ptr, nil)
+
+	case "patternProperties":
+		props, ok := value.(map[string]interface{})
+		if !ok {
+			return invalid("patternProperties must be an object of schemas")
+		}
+		return doc.checkEach(props, ptr, func(pattern string) error {
+			return doc.compile(pattern, invalid)
+		})
+
+	case "dependencies":
+		deps, ok := value.(map[string]interface{})
+		if !ok {
+			return invalid("dependencies must be an object")
+		}
+		schemas := make(map[string]interface{}, len(deps))
+		for key, dep := range deps {
+			names, ok := dep.([]interface{})
+			if !ok {
+				schemas[key] = dep
+				continue
+			}
+			for _, name := range names {
+				if _, ok := name.(string); !ok {
+					return invalid("property dependencies must be arrays of strings")
+				}
+			}
+		}
+		return doc.checkEach(schemas, ptr, nil)
+	}
+	return nil
+}
+
+// checkAll checks the array of subschemas found at the JSON pointer ptr.
+func (doc *jsonSchemaDocument) checkAll(schemas []interface{}, ptr string) error {
+	for i, sub := range schemas {
+		if err := doc.check(sub, ptr+"/"+strconv.Itoa(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEach checks the object of subschemas found at the JSON pointer ptr, in key order. checkKey, if not nil,
+// checks each key.
+func (doc *jsonSchemaDocument) checkEach(schemas map[string]interface{}, ptr string, checkKey func(string) error) error {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if checkKey != nil {
+			if err := checkKey(key); err != nil {
+				return err
+			}
+		}
+		if err := doc.check(schemas[key], ptr+"/"+jsonPointerEscape(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compile compiles the pattern for use during validation, reporting patterns not supported by the regexp package
+// eg. those holding lookarounds.
+func (doc *jsonSchemaDocument) compile(pattern string, invalid func(string, ...interface{}) error) error {
+	if _, ok := doc.regexes[pattern]; ok {
+		return nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return invalid("unsupported pattern %s: %s", pattern, err)
+	}
+	doc.regexes[pattern] = re
+	return nil
+}
+
+// jsonSchemaIsTypeName returns whether the name is one of the JSON Schema types.
+func jsonSchemaIsTypeName(name string) bool {
+	switch name {
+	case "null", "boolean", "object", "array", "number", "string", "integer":
+		return true
+	}
+	return false
+}
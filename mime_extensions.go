@@ -0,0 +1,56 @@
+package validator
+
+var extensionMimeTypes = map[string][]string{
+	// images
+	".png":  {"image/png"},
+	".jpg":  {"image/jpeg", "image/pjpeg"},
+	".jpeg": {"image/jpeg", "image/pjpeg"},
+	".gif":  {"image/gif"},
+	".webp": {"image/webp"},
+	".bmp":  {"image/bmp", "image/x-ms-bmp"},
+	".svg":  {"image/svg+xml"},
+	".ico":  {"image/x-icon", "image/vnd.microsoft.icon"},
+	".tif":  {"image/tiff"},
+	".tiff": {"image/tiff"},
+	".heic": {"image/heic"},
+	".avif": {"image/avif"},
+
+	// audio / video
+	".mp3":  {"audio/mpeg"},
+	".wav":  {"audio/wav", "audio/x-wav"},
+	".ogg":  {"audio/ogg"},
+	".flac": {"audio/flac"},
+	".m4a":  {"audio/mp4", "audio/x-m4a"},
+	".mp4":  {"video/mp4"},
+	".webm": {"video/webm"},
+	".mov":  {"video/quicktime"},
+	".avi":  {"video/x-msvideo"},
+	".mkv":  {"video/x-matroska"},
+
+	// documents
+	".pdf":  {"application/pdf"},
+	".txt":  {"text/plain"},
+	".csv":  {"text/csv"},
+	".html": {"text/html"},
+	".htm":  {"text/html"},
+	".css":  {"text/css"},
+	".js":   {"text/javascript", "application/javascript"},
+	".json": {"application/json"},
+	".xml":  {"application/xml", "text/xml"},
+	".md":   {"text/markdown"},
+	".rtf":  {"application/rtf"},
+	".doc":  {"application/msword"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	".xls":  {"application/vnd.ms-excel"},
+	".xlsx": {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+	".ppt":  {"application/vnd.ms-powerpoint"},
+	".pptx": {"application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+	".odt":  {"application/vnd.oasis.opendocument.text"},
+
+	// archives
+	".zip": {"application/zip", "application/x-zip-compressed"},
+	".gz":  {"application/gzip", "application/x-gzip"},
+	".tar": {"application/x-tar"},
+	".7z":  {"application/x-7z-compressed"},
+	".rar": {"application/vnd.rar", "application/x-rar-compressed"},
+}
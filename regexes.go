@@ -81,6 +81,21 @@ const (
 	spicedbPermissionRegexString     = "^([a-z][a-z0-9_]{1,62}[a-z0-9])?$"
 	spicedbTypeRegexString           = "^([a-z][a-z0-9_]{1,61}[a-z0-9]/)?[a-z][a-z0-9_]{1,62}[a-z0-9]$"
 	einRegexString                   = "^(\\d{2}-\\d{7})$"
+	sqlIdentifierRegexString         = `^[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)?$`
+	jsonPointerRegexString           = `^(/([^~/]|~[01])*)*$`
+	moneyNonNegRegexString           = `^[0-9]+(\.[0-9]{1,2})?$`
+	ibanRegexString                  = `^[A-Z]{2}[0-9]{2}[A-Z0-9]+$`
+	postgresDSNKeyValueRegexString   = `^\s*([a-z_]+)\s*=\s*('(?:[^'\\]|\\.)*'|[^\s']+)`
+	mysqlDSNRegexString              = `^(?:[^@/]*@)?[a-z]+\(([^)]+)\)/([^?/]+)(?:\?.*)?$`
+	dnsNameStrictRegexString         = `^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`
+	dnsLabelRegexString              = `^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`
+	languageSimpleRegexString        = `^[a-zA-Z]{2,3}(-([a-zA-Z]{2}|[0-9]{3}))?$`
+	percentEncodedRegexString        = `^(?:[!-$&-~]|%[0-9A-Fa-f]{2})*$`
+	slugRegexString                  = `^[a-z0-9]+(?:-[a-z0-9]+)*$`
+	shellSafeAllowlistRegexString    = `^[a-zA-Z0-9._/=:,+@%-]*$`
+	phoneExtRegexString              = `(?i)^(.+?)\s*(?:extension|ext\.?|x|#)\s*([0-9]{1,10})?$`
+	uUID7RegexString                 = "^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"
+	iso8601DurationRegexString       = `^P(?:\d+W|(?:\d+Y)?(?:\d+M)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:[.,]\d+)?S)?)?)$`
 )
 
 func lazyRegexCompile(str string) func() *regexp.Regexp {
@@ -168,4 +183,19 @@ var (
 	spicedbPermissionRegex     = lazyRegexCompile(spicedbPermissionRegexString)
 	spicedbTypeRegex           = lazyRegexCompile(spicedbTypeRegexString)
 	einRegex                   = lazyRegexCompile(einRegexString)
+	sqlIdentifierRegex         = lazyRegexCompile(sqlIdentifierRegexString)
+	jsonPointerRegex           = lazyRegexCompile(jsonPointerRegexString)
+	moneyNonNegRegex           = lazyRegexCompile(moneyNonNegRegexString)
+	ibanRegex                  = lazyRegexCompile(ibanRegexString)
+	postgresDSNKeyValueRegex   = lazyRegexCompile(postgresDSNKeyValueRegexString)
+	mysqlDSNRegex              = lazyRegexCompile(mysqlDSNRegexString)
+	dnsNameStrictRegex         = lazyRegexCompile(dnsNameStrictRegexString)
+	dnsLabelRegex              = lazyRegexCompile(dnsLabelRegexString)
+	languageSimpleRegex        = lazyRegexCompile(languageSimpleRegexString)
+	percentEncodedRegex        = lazyRegexCompile(percentEncodedRegexString)
+	slugRegex                  = lazyRegexCompile(slugRegexString)
+	shellSafeAllowlistRegex    = lazyRegexCompile(shellSafeAllowlistRegexString)
+	phoneExtRegex              = lazyRegexCompile(phoneExtRegexString)
+	uUID7Regex                 = lazyRegexCompile(uUID7RegexString)
+	iso8601DurationRegex       = lazyRegexCompile(iso8601DurationRegexString)
 )
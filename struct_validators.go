@@ -0,0 +1,572 @@
+package validator
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"mime"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ExtensionMatchesMime returns a StructLevelFunc which validates that the file extension held in
+// extField corresponds to the MIME type held in mimeField, eg. ".png" and "image/png", in order to
+// catch spoofed uploads where the two disagree. The error is reported against extField.
+//
+// NOTE: when both fields are empty no validation occurs, use field tags to require them.
+func ExtensionMatchesMime(extField, mimeField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		ext := structLevelString(sl, extField)
+		typ := structLevelString(sl, mimeField)
+
+		if len(ext) == 0 && len(typ) == 0 {
+			return
+		}
+
+		if !extensionMatchesMime(ext, typ) {
+			reportStructLevelError(sl, extField, "extension_matches_mime", mimeField)
+		}
+	}
+}
+
+func extensionMatchesMime(ext, typ string) bool {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	mediaType, _, err := mime.ParseMediaType(typ)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range extensionMimeTypes[ext] {
+		if t == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// PhoneMatchesCountry returns a StructLevelFunc which validates that the E.164 phone number held in
+// phoneField has the country calling code of the ISO 3166-1 alpha-2 country code held in countryField,
+// eg. "+8613800138000" and "CN". The error is reported against phoneField.
+//
+// NOTE: when both fields are empty no validation occurs, use field tags to require them.
+func PhoneMatchesCountry(phoneField, countryField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		phone := structLevelString(sl, phoneField)
+		country := structLevelString(sl, countryField)
+
+		if len(phone) == 0 && len(country) == 0 {
+			return
+		}
+
+		if !phoneMatchesCountry(phone, strings.ToUpper(country)) {
+			reportStructLevelError(sl, phoneField, "phone_matches_country", countryField)
+		}
+	}
+}
+
+func phoneMatchesCountry(phone, country string) bool {
+	if !e164Regex().MatchString(phone) {
+		return false
+	}
+
+	digits := strings.TrimPrefix(phone, "+")
+
+	// calling codes are prefix free apart from within the shared +1 zone, so the longest
+	// matching code identifies the country eg. +1876 Jamaica rather than +1 United States.
+	for i := min(len(digits), 4); i > 0; i-- {
+		countries, ok := callingCodeCountries[digits[:i]]
+		if !ok {
+			continue
+		}
+
+		for _, c := range countries {
+			if c == country {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// CRC32Matches returns a StructLevelFunc which validates that the hex encoded CRC-32 (IEEE) checksum held
+// in crcField is the checksum of the string or []byte content held in contentField, as a lightweight
+// integrity check of eg. file chunk uploads. The error is reported against crcField.
+//
+// NOTE: when both fields are empty no validation occurs, use field tags to require them.
+func CRC32Matches(contentField, crcField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		content := structLevelBytes(sl, contentField)
+		crc := structLevelString(sl, crcField)
+
+		if len(content) == 0 && len(crc) == 0 {
+			return
+		}
+
+		if !strings.EqualFold(crc, fmt.Sprintf("%08x", crc32.ChecksumIEEE(content))) {
+			reportStructLevelError(sl, crcField, "crc32_matches", contentField)
+		}
+	}
+}
+
+// EncodingConsistent returns a StructLevelFunc which validates that the string data held in dataField
+// decodes under the encoding declared by encodingField, one of "base64", "hex" or "plain", as for
+// polymorphic payload wrappers. Plain data must be valid UTF-8 and an unknown encoding fails
+// validation. The error is reported against dataField.
+//
+// NOTE: when both fields are empty no validation occurs, use field tags to require them.
+func EncodingConsistent(dataField, encodingField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		data := structLevelString(sl, dataField)
+		encoding := structLevelString(sl, encodingField)
+
+		if len(data) == 0 && len(encoding) == 0 {
+			return
+		}
+
+		if !encodingConsistent(data, strings.ToLower(encoding)) {
+			reportStructLevelError(sl, dataField, "encoding_consistent", encodingField)
+		}
+	}
+}
+
+func encodingConsistent(data, encoding string) bool {
+	var err error
+
+	switch encoding {
+	case "base64":
+		_, err = base64.StdEncoding.DecodeString(data)
+	case "hex":
+		_, err = hex.DecodeString(data)
+	case "plain":
+		return utf8.ValidString(data)
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// ContrastRatio returns a StructLevelFunc which validates that the WCAG contrast ratio between the hex
+// colors held in fgField and bgField, eg. "#333" and "#ffffff", is at least minRatio, eg. 4.5 for normal
+// text at level AA. Any alpha component is ignored and invalid colors fail validation. The error is
+// reported against fgField.
+//
+// NOTE: when both fields are empty no validation occurs, use field tags to require them.
+func ContrastRatio(fgField, bgField string, minRatio float64) StructLevelFunc {
+	param := strconv.FormatFloat(minRatio, 'f', -1, 64)
+
+	return func(sl StructLevel) {
+		fg := structLevelString(sl, fgField)
+		bg := structLevelString(sl, bgField)
+
+		if len(fg) == 0 && len(bg) == 0 {
+			return
+		}
+
+		fgLum, ok := relativeLuminance(fg)
+		if ok {
+			var bgLum float64
+			if bgLum, ok = relativeLuminance(bg); ok {
+				ok = (math.Max(fgLum, bgLum)+0.05)/(math.Min(fgLum, bgLum)+0.05) >= minRatio
+			}
+		}
+
+		if !ok {
+			reportStructLevelError(sl, fgField, "contrast_ratio", param)
+		}
+	}
+}
+
+// relativeLuminance returns the WCAG relative luminance of the hex color, and false if it isn't one.
+func relativeLuminance(color string) (float64, bool) {
+	if !hexColorRegex().MatchString(color) {
+		return 0, false
+	}
+
+	hex := color[1:]
+	if len(hex) <= 4 {
+		// expand the short forms #rgb and #rgba
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	var lum float64
+	for i, weight := range []float64{0.2126, 0.7152, 0.0722} {
+		c, _ := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+
+		v := float64(c) / 255
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		lum += weight * v
+	}
+	return lum, true
+}
+
+// ClosedPolygon returns a StructLevelFunc which validates that the slice of lat/lng points held in
+// pointsField forms a closed ring, being at least 4 points with the first equal to the last, as for
+// geo-fence definitions. The error is reported against pointsField.
+//
+// NOTE: when the slice is empty no validation occurs, use field tags to require it.
+func ClosedPolygon(pointsField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		points, kind := structLevelField(sl, pointsField)
+		if kind != reflect.Slice && kind != reflect.Array {
+			panic(fmt.Sprintf("Bad field type %s", points.Type()))
+		}
+
+		if points.Len() == 0 {
+			return
+		}
+
+		if points.Len() < 4 || !reflect.DeepEqual(points.Index(0).Interface(), points.Index(points.Len()-1).Interface()) {
+			reportStructLevelError(sl, pointsField, "closed_polygon", "")
+		}
+	}
+}
+
+// ContiguousRanges returns a StructLevelFunc which validates that the slice of structs held in sliceField
+// holds ranges, bounded by their integer or float loField and hiField fields, which are sorted and
+// contiguous, each range's low being equal to the previous range's high, as for tax brackets or pricing
+// tiers. The error is reported against sliceField with the first gap or overlap as the param,
+// eg. "gap at index 2".
+//
+// NOTE: when the slice is empty no validation occurs, use field tags to require it.
+func ContiguousRanges(sliceField, loField, hiField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		ranges, kind := structLevelField(sl, sliceField)
+		if kind != reflect.Slice && kind != reflect.Array {
+			panic(fmt.Sprintf("Bad field type %s", ranges.Type()))
+		}
+
+		var prevHi reflect.Value
+
+		for i := 0; i < ranges.Len(); i++ {
+			lo, hi := elemField(ranges.Index(i), loField), elemField(ranges.Index(i), hiField)
+
+			var problem string
+			switch {
+			case compareNumbers(lo, hi) >= 0:
+				problem = "empty range"
+			case i == 0:
+			case compareNumbers(lo, prevHi) > 0:
+				problem = "gap"
+			case compareNumbers(lo, prevHi) < 0:
+				problem = "overlap"
+			}
+
+			if len(problem) > 0 {
+				reportStructLevelError(sl, sliceField, "contiguous_ranges", fmt.Sprintf("%s at index %d", problem, i))
+				return
+			}
+			prevHi = hi
+		}
+	}
+}
+
+// elemField returns the named field of the slice element, being a struct or pointer to one.
+func elemField(elem reflect.Value, name string) reflect.Value {
+	field := reflect.Indirect(elem).FieldByName(name)
+	if !field.IsValid() {
+		panic(fmt.Sprintf("Bad field name %s", name))
+	}
+	return field
+}
+
+// compareNumbers compares two integer or float values of the same kind, returning -1, 0 or +1.
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case a.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.CanFloat():
+		return cmp.Compare(a.Float(), b.Float())
+	}
+	panic(fmt.Sprintf("Bad field type %s", a.Type()))
+}
+
+// SameCurrency returns a StructLevelFunc which validates that the ISO 4217 currency codes held in currencyAField
+// and currencyBField, of the amounts held in amountAField and amountBField, are the same regardless of case, so that
+// the amounts may safely be compared or summed elsewhere. The amount fields must exist but are otherwise unchecked.
+// The error is reported against currencyBField.
+//
+// NOTE: when both currency fields are empty no validation occurs, use field tags to require them.
+func SameCurrency(amountAField, currencyAField, amountBField, currencyBField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		structLevelField(sl, amountAField)
+		structLevelField(sl, amountBField)
+
+		currencyA := structLevelString(sl, currencyAField)
+		currencyB := structLevelString(sl, currencyBField)
+
+		if len(currencyA) == 0 && len(currencyB) == 0 {
+			return
+		}
+
+		if !strings.EqualFold(currencyA, currencyB) {
+			reportStructLevelError(sl, currencyBField, "same_currency", currencyAField)
+		}
+	}
+}
+
+// DiscriminatedUnion returns a StructLevelFunc which validates polymorphic structs whose string typeField selects,
+// via mapping, the name of the payload field in use eg. {"card": "Card", "bank": "Bank"}. The selected payload field
+// must be present, being non-zero eg. a non-nil pointer, and all other payload fields must be absent, while the
+// payloads themselves are validated by their own tags as usual. An unknown type is reported against typeField,
+// with the space separated known types as the param, and payload errors against the payload field, with the type as
+// the param.
+//
+// NOTE: when the type field is empty no validation occurs, use field tags to require it.
+func DiscriminatedUnion(typeField string, mapping map[string]string) StructLevelFunc {
+	types := make([]string, 0, len(mapping))
+	for t := range mapping {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	known := strings.Join(types, " ")
+
+	return func(sl StructLevel) {
+		typ := structLevelString(sl, typeField)
+		if len(typ) == 0 {
+			return
+		}
+
+		selected, ok := mapping[typ]
+		if !ok {
+			reportStructLevelError(sl, typeField, "discriminated_union", known)
+			return
+		}
+
+		for _, t := range types {
+			payload := sl.Current().FieldByName(mapping[t])
+			if !payload.IsValid() {
+				panic(fmt.Sprintf("Bad field name %s", mapping[t]))
+			}
+
+			if present := !payload.IsZero(); present != (mapping[t] == selected) {
+				reportStructLevelError(sl, mapping[t], "discriminated_union", typ)
+			}
+		}
+	}
+}
+
+// UUIDv7Ordered returns a StructLevelFunc which validates that the UUIDv7 string idField of the structs, or pointers
+// to structs, of the slice held in sliceField have non-decreasing embedded millisecond timestamps, as for ordered
+// event batches. The error is reported against sliceField with the first problem as the param,
+// eg. "out of order at index 2" or "invalid at index 0" for an ID which isn't a UUIDv7.
+//
+// NOTE: when the slice is empty no validation occurs, use field tags to require it.
+func UUIDv7Ordered(sliceField, idField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		events, kind := structLevelField(sl, sliceField)
+		if kind != reflect.Slice && kind != reflect.Array {
+			panic(fmt.Sprintf("Bad field type %s", events.Type()))
+		}
+
+		var prev uint64
+
+		for i := 0; i < events.Len(); i++ {
+			id := elemField(events.Index(i), idField)
+			if id.Kind() != reflect.String {
+				panic(fmt.Sprintf("Bad field type %s", id.Type()))
+			}
+
+			uuid := strings.ToLower(id.String())
+			if !uUID7Regex().MatchString(uuid) {
+				reportStructLevelError(sl, sliceField, "uuid7_ordered", fmt.Sprintf("invalid at index %d", i))
+				return
+			}
+
+			// the first 48 bits are the unix timestamp in milliseconds
+			ts, _ := strconv.ParseUint(uuid[:8]+uuid[9:13], 16, 64)
+			if ts < prev {
+				reportStructLevelError(sl, sliceField, "uuid7_ordered", fmt.Sprintf("out of order at index %d", i))
+				return
+			}
+			prev = ts
+		}
+	}
+}
+
+// OffsetMatchesZone returns a StructLevelFunc which validates that the UTC offset held in offsetField, eg. "+02:00",
+// "+0200" or "Z", is the actual offset of the IANA time zone named by zoneField, eg. "Europe/Berlin", at the instant
+// held by the time.Time timeField, accounting for daylight saving time. Unknown zones and malformed offsets fail
+// validation. The error is reported against offsetField.
+//
+// NOTE: when both the zone and offset fields are empty no validation occurs, use field tags to require them.
+func OffsetMatchesZone(timeField, zoneField, offsetField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		field, _ := structLevelField(sl, timeField)
+		instant := asTime(field)
+
+		zone := structLevelString(sl, zoneField)
+		offset := structLevelString(sl, offsetField)
+
+		if len(zone) == 0 && len(offset) == 0 {
+			return
+		}
+
+		if !offsetMatchesZone(instant, zone, offset) {
+			reportStructLevelError(sl, offsetField, "offset_matches_zone", zoneField)
+		}
+	}
+}
+
+func offsetMatchesZone(instant time.Time, zone, offset string) bool {
+	loc, err := time.LoadLocation(zone)
+	if err != nil || len(zone) == 0 {
+		return false
+	}
+
+	declared, err := time.Parse("Z07:00", offset)
+	if err != nil {
+		if declared, err = time.Parse("Z0700", offset); err != nil {
+			return false
+		}
+	}
+
+	_, want := instant.In(loc).Zone()
+	_, got := declared.Zone()
+	return got == want
+}
+
+// weightsSumEpsilon is the absolute difference allowed between the sum of weights and the WeightsSumTo target.
+const weightsSumEpsilon = 1e-6
+
+// WeightsSumTo returns a StructLevelFunc which validates that the integer or float weightField of the structs, or
+// pointers to structs, of the slice held in sliceField sum to target, within a small tolerance, as for A/B experiment
+// variants which must sum to 1.0 or 100. The error is reported against sliceField with the actual sum as the param.
+//
+// NOTE: when the slice is empty no validation occurs, use field tags to require it.
+func WeightsSumTo(sliceField, weightField string, target float64) StructLevelFunc {
+	return func(sl StructLevel) {
+		items, kind := structLevelField(sl, sliceField)
+		if kind != reflect.Slice && kind != reflect.Array {
+			panic(fmt.Sprintf("Bad field type %s", items.Type()))
+		}
+
+		if items.Len() == 0 {
+			return
+		}
+
+		var sum float64
+		for i := 0; i < items.Len(); i++ {
+			weight := elemField(items.Index(i), weightField)
+
+			switch {
+			case weight.CanInt():
+				sum += float64(weight.Int())
+			case weight.CanUint():
+				sum += float64(weight.Uint())
+			case weight.CanFloat():
+				sum += weight.Float()
+			default:
+				panic(fmt.Sprintf("Bad field type %s", weight.Type()))
+			}
+		}
+
+		if math.Abs(sum-target) > weightsSumEpsilon {
+			// round away accumulated floating point error from the reported sum
+			reportStructLevelError(sl, sliceField, "weights_sum_to", strconv.FormatFloat(math.Round(sum*1e6)/1e6, 'f', -1, 64))
+		}
+	}
+}
+
+// SubnetWithin returns a StructLevelFunc which validates that the CIDR subnet held in subnetField, eg. "10.1.2.0/24",
+// is fully contained within the parent CIDR held in parentField, eg. "10.0.0.0/8", as for IPAM allocation requests.
+// Both must be of the same IP version and invalid CIDRs fail validation. The error is reported against subnetField.
+//
+// NOTE: when both fields are empty no validation occurs, use field tags to require them.
+func SubnetWithin(subnetField, parentField string) StructLevelFunc {
+	return func(sl StructLevel) {
+		subnet := structLevelString(sl, subnetField)
+		parent := structLevelString(sl, parentField)
+
+		if len(subnet) == 0 && len(parent) == 0 {
+			return
+		}
+
+		if !subnetWithin(subnet, parent) {
+			reportStructLevelError(sl, subnetField, "subnet_within", parentField)
+		}
+	}
+}
+
+func subnetWithin(subnet, parent string) bool {
+	sub, err := netip.ParsePrefix(subnet)
+	if err != nil {
+		return false
+	}
+
+	par, err := netip.ParsePrefix(parent)
+	if err != nil {
+		return false
+	}
+
+	return sub.Addr().Is4() == par.Addr().Is4() && par.Bits() <= sub.Bits() && par.Contains(sub.Masked().Addr())
+}
+
+// structLevelField returns the underlying value of the named field on the struct currently being
+// validated and panics if no such field exists.
+func structLevelField(sl StructLevel, name string) (reflect.Value, reflect.Kind) {
+	field := sl.Current().FieldByName(name)
+	if !field.IsValid() {
+		panic(fmt.Sprintf("Bad field name %s", name))
+	}
+
+	current, kind, _ := sl.ExtractType(field)
+	return current, kind
+}
+
+// structLevelString returns the string value of the named field on the struct currently being validated.
+func structLevelString(sl StructLevel, name string) string {
+	field, kind := structLevelField(sl, name)
+	if kind != reflect.String {
+		panic(fmt.Sprintf("Bad field type %s", field.Type()))
+	}
+	return field.String()
+}
+
+// structLevelBytes returns the string or []byte value of the named field on the struct currently being validated.
+func structLevelBytes(sl StructLevel, name string) []byte {
+	field, kind := structLevelField(sl, name)
+
+	switch {
+	case kind == reflect.String:
+		return []byte(field.String())
+	case kind == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		return field.Bytes()
+	}
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
+
+// reportStructLevelError reports an error against the named field of the struct currently being
+// validated, honouring any registered TagNameFunc for the reported field name.
+func reportStructLevelError(sl StructLevel, name, tag, param string) {
+	current := sl.Current()
+	fieldName := name
+
+	if v := sl.Validator(); v.hasTagNameFunc {
+		if sf, ok := current.Type().FieldByName(name); ok {
+			if altName := v.tagNameFunc(sf); len(altName) > 0 {
+				fieldName = altName
+			}
+		}
+	}
+
+	sl.ReportError(current.FieldByName(name).Interface(), fieldName, name, tag, param)
+}
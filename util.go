@@ -257,6 +257,31 @@ func asUint(param string) uint64 {
 	return i
 }
 
+// byteSizeUnits are the binary multiples accepted by asByteSize, longest suffix first.
+var byteSizeUnits = []struct {
+	suffix string
+	size   uint64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// asByteSize returns the parameter, a number of bytes optionally followed by
+// a B, KB, MB, GB or TB unit, as a uint64 or panics if it can't convert
+func asByteSize(param string) uint64 {
+	upper := strings.ToUpper(strings.TrimSpace(param))
+
+	for _, u := range byteSizeUnits {
+		if n, ok := strings.CutSuffix(upper, u.suffix); ok {
+			return asUint(strings.TrimSpace(n)) * u.size
+		}
+	}
+	return asUint(upper)
+}
+
 // asFloat64 returns the parameter as a float64
 // or panics if it can't convert
 func asFloat64(param string) float64 {
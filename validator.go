@@ -26,6 +26,7 @@ type validate struct {
 	str1           string        // misc reusable
 	str2           string        // misc reusable
 	fldIsPointer   bool          // StructLevel & FieldLevel
+	failParams     []string      // FieldLevel, params reported in place of the tag's param on failure, one error each
 	isPartial      bool
 	hasExcludes    bool
 }
@@ -133,6 +134,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 						structfieldLen: uint8(len(cf.name)),
 						param:          ct.param,
 						kind:           kind,
+						severity:       ct.severity,
 					},
 				)
 				return
@@ -158,6 +160,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 						param:          ct.param,
 						kind:           kind,
 						typ:            current.Type(),
+						severity:       ct.severity,
 					},
 				)
 				return
@@ -431,6 +434,7 @@ OUTER:
 								param:          ct.param,
 								kind:           kind,
 								typ:            typ,
+								severity:       ct.severity,
 							},
 						)
 					} else {
@@ -449,6 +453,7 @@ OUTER:
 								param:          ct.param,
 								kind:           kind,
 								typ:            typ,
+								severity:       ct.severity,
 							},
 						)
 					}
@@ -466,6 +471,7 @@ OUTER:
 			v.flField = current
 			v.cf = cf
 			v.ct = ct
+			v.failParams = v.failParams[:0]
 
 			if !ct.fn(ctx, v) {
 				v.str1 = string(append(ns, cf.altName...))
@@ -476,21 +482,29 @@ OUTER:
 					v.str2 = v.str1
 				}
 
-				v.errs = append(v.errs,
-					&fieldError{
-						v:              v.v,
-						tag:            ct.aliasTag,
-						actualTag:      ct.tag,
-						ns:             v.str1,
-						structNs:       v.str2,
-						fieldLen:       uint8(len(cf.altName)),
-						structfieldLen: uint8(len(cf.name)),
-						value:          getValue(current),
-						param:          ct.param,
-						kind:           kind,
-						typ:            typ,
-					},
-				)
+				params := v.failParams
+				if len(params) == 0 {
+					params = append(params, ct.param)
+				}
+
+				for _, param := range params {
+					v.errs = append(v.errs,
+						&fieldError{
+							v:              v.v,
+							tag:            ct.aliasTag,
+							actualTag:      ct.tag,
+							ns:             v.str1,
+							structNs:       v.str2,
+							fieldLen:       uint8(len(cf.altName)),
+							structfieldLen: uint8(len(cf.name)),
+							value:          getValue(current),
+							param:          param,
+							kind:           kind,
+							typ:            typ,
+							severity:       ct.severity,
+						},
+					)
+				}
 
 				return
 			}
@@ -521,3 +535,9 @@ func getValue(val reflect.Value) interface{} {
 		return val.String()
 	}
 }
+
+// reportFailParam sets the param reported by the error of the validation currently running, should it fail,
+// in place of the tag's param eg. the value actually found. Each param reported results in its own error.
+func (v *validate) reportFailParam(param string) {
+	v.failParams = append(v.failParams, param)
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	ut "github.com/go-playground/universal-translator"
@@ -30,6 +31,7 @@ const (
 	requiredWithTag       = "required_with"
 	requiredWithAllTag    = "required_with_all"
 	requiredIfTag         = "required_if"
+	requiredSliceIfTag    = "required_slice_if"
 	requiredUnlessTag     = "required_unless"
 	skipUnlessTag         = "skip_unless"
 	excludedWithoutAllTag = "excluded_without_all"
@@ -43,6 +45,7 @@ const (
 	keysTag               = "keys"
 	endKeysTag            = "endkeys"
 	requiredTag           = "required"
+	nullableTag           = "nullable"
 	namespaceSeparator    = "."
 	leftBracket           = "["
 	rightBracket          = "]"
@@ -74,9 +77,39 @@ type CustomTypeFunc func(field reflect.Value) interface{}
 // TagNameFunc allows for adding of a custom tag name parser
 type TagNameFunc func(field reflect.StructField) string
 
+// FKResolverFunc reports whether the provided id exists within the set of ids it resolves, eg. the
+// primary keys of a database table, for use by the fk_exists validation.
+type FKResolverFunc func(ctx context.Context, id interface{}) (bool, error)
+
+// PrincipalExtractorFunc returns the ID of the authenticated principal held by the context, and false
+// when there is none, for use by the eq_current_user validation.
+type PrincipalExtractorFunc func(ctx context.Context) (string, bool)
+
+// SequenceProviderFunc returns the last sequence number stored for the key, eg. a tenant ID, for use by the
+// sequence_gt_key validation. Keys without a stored sequence should return 0.
+type SequenceProviderFunc func(ctx context.Context, key string) (int64, error)
+
+// VersionProviderFunc returns the version currently stored for the resource ID, for use by the version_gt_stored
+// validation. Resources without a stored version should return 0.
+type VersionProviderFunc func(ctx context.Context, resourceID string) (int64, error)
+
+// RateLimiter counts the events of subjects within a rolling window, for use by the rate_limit validation.
+type RateLimiter interface {
+	// Allow records an event for the subject identified by key and reports whether no more
+	// than limit events, including this one, have occurred within the window.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+}
+
+// NonceStore records the nonces already seen, for use by the unique_nonce validation.
+type NonceStore interface {
+	// Record atomically records the nonce and reports whether it had not been seen before.
+	Record(ctx context.Context, nonce string) (bool, error)
+}
+
 type internalValidationFuncWrapper struct {
 	fn                 FuncCtx
 	runValidationOnNil bool
+	severity           Severity
 }
 
 // Validate contains the validator settings and cache
@@ -90,6 +123,18 @@ type Validate struct {
 	validations            map[string]internalValidationFuncWrapper
 	transTagFunc           map[ut.Translator]map[string]TranslationFunc // map[<locale>]map[<tag>]TranslationFunc
 	rules                  map[reflect.Type]map[string]string
+	fkResolvers            map[string]FKResolverFunc
+	principalExtractor     PrincipalExtractorFunc
+	rateLimiter            RateLimiter
+	nonceStore             NonceStore
+	sequenceProvider       SequenceProviderFunc
+	versionProvider        VersionProviderFunc
+	hmacSecrets            map[string][]byte
+	protoEnums             map[string]map[int32]string
+	namedFormats           map[string][]*cTag
+	templateNames          *atomic.Pointer[map[string]struct{}]
+	featureFlags           *atomic.Pointer[map[string]struct{}]
+	jsonSchemas            map[string]*jsonSchemaDocument
 	tagCache               *tagCache
 	structCache            *structCache
 	hasCustomFuncs         bool
@@ -111,11 +156,13 @@ func New(options ...Option) *Validate {
 	sc.m.Store(make(map[reflect.Type]*cStruct))
 
 	v := &Validate{
-		tagName:     defaultTagName,
-		aliases:     make(map[string]string, len(bakedInAliases)),
-		validations: make(map[string]internalValidationFuncWrapper, len(bakedInValidators)),
-		tagCache:    tc,
-		structCache: sc,
+		tagName:       defaultTagName,
+		aliases:       make(map[string]string, len(bakedInAliases)),
+		validations:   make(map[string]internalValidationFuncWrapper, len(bakedInValidators)+len(bakedInValidatorsCtx)),
+		tagCache:      tc,
+		structCache:   sc,
+		templateNames: new(atomic.Pointer[map[string]struct{}]),
+		featureFlags:  new(atomic.Pointer[map[string]struct{}]),
 	}
 
 	// must copy alias validators for separate validations to be used in each validator instance
@@ -129,7 +176,7 @@ func New(options ...Option) *Validate {
 		// these require that even if the value is nil that the validation should run, omitempty still overrides this behaviour
 		case requiredIfTag, requiredUnlessTag, requiredWithTag, requiredWithAllTag, requiredWithoutTag, requiredWithoutAllTag,
 			excludedIfTag, excludedUnlessTag, excludedWithTag, excludedWithAllTag, excludedWithoutTag, excludedWithoutAllTag,
-			skipUnlessTag:
+			skipUnlessTag, requiredSliceIfTag:
 			_ = v.registerValidation(k, wrapFunc(val), true, true)
 		default:
 			// no need to error check here, baked in will always be valid
@@ -137,6 +184,16 @@ func New(options ...Option) *Validate {
 		}
 	}
 
+	for k, val := range bakedInValidatorsCtx {
+		switch k {
+		// nullable passes for nil values itself so must always be run
+		case nullableTag:
+			_ = v.registerValidation(k, val, true, true)
+		default:
+			_ = v.registerValidation(k, val, true, false)
+		}
+	}
+
 	v.pool = &sync.Pool{
 		New: func() interface{} {
 			return &validate{
@@ -231,6 +288,173 @@ func (v *Validate) RegisterValidationCtx(tag string, fn FuncCtx, callValidationE
 	return v.registerValidation(tag, fn, false, nilCheckable)
 }
 
+// RegisterValidationWithSeverity does the same as RegisterValidation but reports any failure of
+// the validation with the provided Severity, see FieldError.Severity.
+func (v *Validate) RegisterValidationWithSeverity(tag string, fn Func, severity Severity, callValidationEvenIfNull ...bool) error {
+	if err := v.RegisterValidation(tag, fn, callValidationEvenIfNull...); err != nil {
+		return err
+	}
+
+	wrapper := v.validations[tag]
+	wrapper.severity = severity
+	v.validations[tag] = wrapper
+	return nil
+}
+
+// RegisterFKResolver registers a FKResolverFunc under the provided name, for use by the
+// fk_exists validation eg. fk_exists=category.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterFKResolver(name string, fn FKResolverFunc) {
+	if v.fkResolvers == nil {
+		v.fkResolvers = make(map[string]FKResolverFunc)
+	}
+	v.fkResolvers[name] = fn
+}
+
+// RegisterPrincipalExtractor registers the PrincipalExtractorFunc used by the eq_current_user validation
+// to read the authenticated principal's ID from the context passed to validation.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterPrincipalExtractor(fn PrincipalExtractorFunc) {
+	v.principalExtractor = fn
+}
+
+// RegisterRateLimiter registers the RateLimiter store consulted by the rate_limit validation.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterRateLimiter(store RateLimiter) {
+	v.rateLimiter = store
+}
+
+// RegisterNonceStore registers the NonceStore consulted by the unique_nonce validation.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterNonceStore(store NonceStore) {
+	v.nonceStore = store
+}
+
+// RegisterSequenceProvider registers the SequenceProviderFunc used by the sequence_gt_key validation to read
+// the last sequence number stored per key.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterSequenceProvider(fn SequenceProviderFunc) {
+	v.sequenceProvider = fn
+}
+
+// RegisterVersionProvider registers the VersionProviderFunc used by the version_gt_stored validation to read
+// the version stored per resource, for optimistic concurrency checks.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterVersionProvider(fn VersionProviderFunc) {
+	v.versionProvider = fn
+}
+
+// RegisterHMACSecret registers the secret under the provided name, for use by the hmac_sig validation
+// eg. hmac_sig=secretKey=WebhookSecret over=RawBody.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterHMACSecret(name string, secret []byte) {
+	if v.hmacSecrets == nil {
+		v.hmacSecrets = make(map[string][]byte)
+	}
+	v.hmacSecrets[name] = secret
+}
+
+// RegisterProtoEnum registers the values of the protobuf enum with the provided full name, for use by the
+// proto_enum validation eg. proto_enum=acme.v1.Status. The values are those of the generated <Enum>_name
+// map, so that no dependency on the protobuf runtime is required.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterProtoEnum(fullName string, values map[int32]string) {
+	if v.protoEnums == nil {
+		v.protoEnums = make(map[string]map[int32]string)
+	}
+	v.protoEnums[fullName] = values
+}
+
+// RegisterNamedFormat registers a composite format under the provided name, for use by the named_format
+// validation eg. named_format=contact, which passes if any of the formats' validations does. Each format is
+// a registered validation tag, optionally with a param eg. "email", "e164" or "min=3", and must already be
+// registered, otherwise this panics.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterNamedFormat(name string, formats ...string) {
+	cts := make([]*cTag, 0, len(formats))
+
+	for _, format := range formats {
+		tag, param, hasParam := strings.Cut(format, tagKeySeparator)
+
+		wrapper, ok := v.validations[tag]
+		if !ok || tag == nullableTag {
+			panic(fmt.Sprintf("Undefined validation function '%s' on named format '%s'", tag, name))
+		}
+		cts = append(cts, &cTag{tag: tag, aliasTag: tag, param: param, hasParam: hasParam, hasTag: true, fn: wrapper.fn})
+	}
+
+	if v.namedFormats == nil {
+		v.namedFormats = make(map[string][]*cTag)
+	}
+	v.namedFormats[name] = cts
+}
+
+// rawValueKey is the context key type under which ContextWithRawValue stores raw values.
+type rawValueKey string
+
+// ContextWithRawValue returns a copy of ctx holding the raw value under the provided name, eg. the raw body of a
+// webhook request, for use by the hmac_sig validation's over param.
+func ContextWithRawValue(ctx context.Context, name string, value []byte) context.Context {
+	return context.WithValue(ctx, rawValueKey(name), value)
+}
+
+// contentLengthKey is the context key under which ContextWithContentLength stores the content length.
+type contentLengthKey struct{}
+
+// ContextWithContentLength returns a copy of ctx holding the request's content length, eg. http.Request's
+// ContentLength, for use by the content_length_eq validation.
+func ContextWithContentLength(ctx context.Context, length int64) context.Context {
+	return context.WithValue(ctx, contentLengthKey{}, length)
+}
+
+// RegisterTemplateNames sets the known template names accepted by the template_name validation,
+// replacing any previously registered. It is safe to call concurrently with validation, eg. to reload them.
+func (v *Validate) RegisterTemplateNames(names ...string) {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	v.templateNames.Store(&set)
+}
+
+// RegisterFeatureFlags sets the known feature flag keys accepted by the feature_flag validation,
+// replacing any previously registered. It is safe to call concurrently with validation, eg. to reload them.
+func (v *Validate) RegisterFeatureFlags(keys ...string) {
+	flags := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		flags[key] = struct{}{}
+	}
+	v.featureFlags.Store(&flags)
+}
+
+// RegisterJSONSchemaDocument registers the draft-07 JSON Schema document under the provided name, for use by the
+// jsonschema validation eg. jsonschema=ProductSchema. An error is returned if the document isn't valid JSON,
+// isn't a JSON object or boolean, or holds invalid keywords eg. an unknown type, a non-numeric minLength or a
+// pattern not supported by the regexp package.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterJSONSchemaDocument(name string, schema []byte) error {
+	doc, err := parseJSONSchemaDocument(schema)
+	if err != nil {
+		return err
+	}
+
+	if v.jsonSchemas == nil {
+		v.jsonSchemas = make(map[string]*jsonSchemaDocument)
+	}
+	v.jsonSchemas[name] = doc
+	return nil
+}
+
 // RegisterAlias registers a mapping of a single validation tag that
 // defines a common or complex set of validation(s) to simplify adding validation
 // to structs.
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// vatFormat is the structure of the VAT identification numbers of a country, the number
+// following the prefix being matched by regex and, where known, verified by checksum.
+type vatFormat struct {
+	prefix   string
+	regex    func() *regexp.Regexp
+	checksum func(number string) bool
+}
+
+var vatFormats = map[string]vatFormat{
+	// see: https://ec.europa.eu/taxation_customs/vies/
+	"AT": {prefix: "AT", regex: lazyRegexCompile(`^U\d{8}$`)},
+	"BE": {prefix: "BE", regex: lazyRegexCompile(`^[01]\d{9}$`)},
+	"BG": {prefix: "BG", regex: lazyRegexCompile(`^\d{9,10}$`)},
+	"CH": {prefix: "CHE", regex: lazyRegexCompile(`^\d{9}(MWST|TVA|IVA)?$`)},
+	"CY": {prefix: "CY", regex: lazyRegexCompile(`^\d{8}[A-Z]$`)},
+	"CZ": {prefix: "CZ", regex: lazyRegexCompile(`^\d{8,10}$`)},
+	"DE": {prefix: "DE", regex: lazyRegexCompile(`^\d{9}$`), checksum: vatChecksumDE},
+	"DK": {prefix: "DK", regex: lazyRegexCompile(`^\d{8}$`)},
+	"EE": {prefix: "EE", regex: lazyRegexCompile(`^\d{9}$`)},
+	"EL": {prefix: "EL", regex: lazyRegexCompile(`^\d{9}$`)},
+	"ES": {prefix: "ES", regex: lazyRegexCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`)},
+	"FI": {prefix: "FI", regex: lazyRegexCompile(`^\d{8}$`)},
+	"FR": {prefix: "FR", regex: lazyRegexCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`), checksum: vatChecksumFR},
+	"GB": {prefix: "GB", regex: lazyRegexCompile(`^(\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`)},
+	"GR": {prefix: "EL", regex: lazyRegexCompile(`^\d{9}$`)},
+	"HR": {prefix: "HR", regex: lazyRegexCompile(`^\d{11}$`)},
+	"HU": {prefix: "HU", regex: lazyRegexCompile(`^\d{8}$`)},
+	"IE": {prefix: "IE", regex: lazyRegexCompile(`^(\d{7}[A-W][A-IW]?|\d[A-Z+*]\d{5}[A-W])$`)},
+	"IT": {prefix: "IT", regex: lazyRegexCompile(`^\d{11}$`)},
+	"LT": {prefix: "LT", regex: lazyRegexCompile(`^(\d{9}|\d{12})$`)},
+	"LU": {prefix: "LU", regex: lazyRegexCompile(`^\d{8}$`)},
+	"LV": {prefix: "LV", regex: lazyRegexCompile(`^\d{11}$`)},
+	"MT": {prefix: "MT", regex: lazyRegexCompile(`^\d{8}$`)},
+	"NL": {prefix: "NL", regex: lazyRegexCompile(`^\d{9}B\d{2}$`)},
+	"NO": {prefix: "NO", regex: lazyRegexCompile(`^\d{9}(MVA)?$`)},
+	"PL": {prefix: "PL", regex: lazyRegexCompile(`^\d{10}$`)},
+	"PT": {prefix: "PT", regex: lazyRegexCompile(`^\d{9}$`)},
+	"RO": {prefix: "RO", regex: lazyRegexCompile(`^[1-9]\d{1,9}$`)},
+	"SE": {prefix: "SE", regex: lazyRegexCompile(`^\d{10}01$`)},
+	"SI": {prefix: "SI", regex: lazyRegexCompile(`^\d{8}$`)},
+	"SK": {prefix: "SK", regex: lazyRegexCompile(`^\d{10}$`)},
+	"XI": {prefix: "XI", regex: lazyRegexCompile(`^(\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`)},
+}
+
+// vatChecksumDE verifies the ISO 7064 MOD 11,10 check digit of a German USt-IdNr.
+func vatChecksumDE(number string) bool {
+	product := 10
+	for _, c := range number[:8] {
+		sum := (int(c-'0') + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == int(number[8]-'0')
+}
+
+// vatChecksumFR verifies the numeric validation key of a French numéro de TVA against its SIREN.
+func vatChecksumFR(number string) bool {
+	key, err := strconv.Atoi(number[:2])
+	if err != nil {
+		// alphabetic keys are from a newer scheme without a published check
+		return true
+	}
+
+	siren, _ := strconv.Atoi(number[2:])
+	return key == (12+3*(siren%97))%97
+}